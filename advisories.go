@@ -0,0 +1,108 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GitHubAPIURL is the base of the GitHub REST API
+const GitHubAPIURL = "https://api.github.com"
+
+// advisorySeverities maps the severities to their rank, lowest first
+var advisorySeverities = map[string]string{
+	"low":      "1",
+	"medium":   "2",
+	"high":     "3",
+	"critical": "4",
+}
+
+func init() {
+	Register(Source{
+		Name:        "advisories",
+		Description: "Recent security advisories from the GitHub Advisory Database",
+		Flags:       []string{"ecosystem", "severity"},
+		New: func(opts Options) Fetcher {
+			return &AdvisoriesSource{Client: opts.Client, Ecosystems: opts.Ecosystems, Severity: opts.Severity}
+		},
+	})
+}
+
+// gitHubAdvisory is an advisory as returned by the global advisories endpoint
+type gitHubAdvisory struct {
+	GHSAID      string `json:"ghsa_id"`
+	CVEID       string `json:"cve_id"`
+	HTMLURL     string `json:"html_url"`
+	Summary     string `json:"summary"`
+	Severity    string `json:"severity"`
+	PublishedAt string `json:"published_at"`
+}
+
+// AdvisoriesSource fetches the newest reviewed advisories of
+// https://github.com/advisories, which also carry the CVEs published by NVD
+type AdvisoriesSource struct {
+	Client *http.Client
+	// Ecosystems such as go or npm, all of them when empty
+	Ecosystems []string
+	// Severity is the lowest severity kept: low (default), medium, high
+	// or critical
+	Severity string
+}
+
+// Fetch gets the newest advisories. With ecosystems, the advisories of every
+// ecosystem are interleaved.
+func (l *AdvisoriesSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	min, err := lookupMode("advisories severity", l.Severity, "low", advisorySeverities)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.Ecosystems) == 0 {
+		return l.fetchAdvisories(ctx, "", min, count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Ecosystems)-1, len(l.Ecosystems), func(ctx context.Context, i int) ([]Story, error) {
+		return l.fetchAdvisories(ctx, l.Ecosystems[i], min, count)
+	})
+	return interleave(lists, count), err
+}
+
+// fetchAdvisories gets up to count advisories at least as severe as the min
+// rank, of every ecosystem when ecosystem is empty
+func (l *AdvisoriesSource) fetchAdvisories(ctx context.Context, ecosystem, min string, count int) ([]Story, error) {
+	v := url.Values{}
+	v.Set("type", "reviewed")
+	// fetch a full page, as the severity is filtered here
+	v.Set("per_page", "100")
+	if ecosystem != "" {
+		v.Set("ecosystem", strings.ToLower(ecosystem))
+	}
+
+	var advisories []gitHubAdvisory
+	if err := getJSON(ctx, l.Client, GitHubAPIURL+"/advisories?"+v.Encode(), &advisories); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, advisory := range advisories {
+		// advisories without a known severity are kept
+		if rank, ok := advisorySeverities[advisory.Severity]; ok && rank < min {
+			continue
+		}
+
+		id := advisory.CVEID
+		if id == "" {
+			id = advisory.GHSAID
+		}
+		news = append(news, Story{
+			Title:       fmt.Sprintf("[%s] %s: %s", advisory.Severity, id, strings.TrimSpace(advisory.Summary)),
+			URL:         advisory.HTMLURL,
+			Source:      "advisories",
+			PublishedAt: parseTime(advisory.PublishedAt),
+		})
+	}
+
+	return truncate(news, count), nil
+}
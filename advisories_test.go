@@ -0,0 +1,43 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const gitHubAdvisories = `[
+	{"ghsa_id": "GHSA-aaaa", "cve_id": "CVE-2018-0001", "html_url": "https://github.com/advisories/GHSA-aaaa", "summary": "Remote code execution", "severity": "critical", "published_at": "2018-10-02T15:04:05Z"},
+	{"ghsa_id": "GHSA-bbbb", "cve_id": null, "html_url": "https://github.com/advisories/GHSA-bbbb", "summary": "Information leak", "severity": "medium", "published_at": "2018-10-01T15:04:05Z"},
+	{"ghsa_id": "GHSA-cccc", "cve_id": "CVE-2018-0003", "html_url": "https://github.com/advisories/GHSA-cccc", "summary": "Denial of service", "severity": "high", "published_at": "2018-09-30T15:04:05Z"}
+]`
+
+func TestAdvisoriesFetch(t *testing.T) {
+	var ecosystems []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/advisories", r.URL.Path)
+		assert.Equal(t, "reviewed", r.URL.Query().Get("type"))
+		ecosystems = append(ecosystems, r.URL.Query().Get("ecosystem"))
+		w.Write([]byte(gitHubAdvisories))
+	}))
+	defer done()
+
+	news, err := (&AdvisoriesSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "[critical] CVE-2018-0001: Remote code execution", news[0].Title)
+		assert.Equal(t, "https://github.com/advisories/GHSA-aaaa", news[0].URL)
+		assert.Equal(t, "[medium] GHSA-bbbb: Information leak", news[1].Title)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+	}
+
+	news, err = (&AdvisoriesSource{Client: client, Ecosystems: []string{"Go"}, Severity: "high"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, []string{"", "go"}, ecosystems)
+
+	_, err = (&AdvisoriesSource{Client: client, Severity: "meh"}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,105 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HackerNewsSearchURL is the base of the Algolia Hacker News search API
+const HackerNewsSearchURL = "https://hn.algolia.com/api/v1"
+
+// SearchQuery describes a Hacker News search. Zero values don't filter.
+type SearchQuery struct {
+	Query string
+	// After and Before limit the submission date
+	After, Before time.Time
+	// MinPoints drops stories with fewer points
+	MinPoints int
+	// ByDate returns the newest matches first instead of the most relevant
+	ByDate bool
+}
+
+// values encodes the query for the search endpoint
+func (q SearchQuery) values(count int) url.Values {
+	v := url.Values{}
+	v.Set("query", q.Query)
+	v.Set("tags", "story")
+	v.Set("hitsPerPage", strconv.Itoa(count))
+
+	var filters []string
+	if !q.After.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i>=%d", q.After.Unix()))
+	}
+	if !q.Before.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i<%d", q.Before.Unix()))
+	}
+	if q.MinPoints > 0 {
+		filters = append(filters, fmt.Sprintf("points>=%d", q.MinPoints))
+	}
+	if len(filters) > 0 {
+		v.Set("numericFilters", strings.Join(filters, ","))
+	}
+
+	return v
+}
+
+// algoliaResponse decodes the search results
+type algoliaResponse struct {
+	Hits []struct {
+		ObjectID    string `json:"objectID"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		Author      string `json:"author"`
+		Points      int    `json:"points"`
+		NumComments int    `json:"num_comments"`
+		CreatedAtI  int64  `json:"created_at_i"`
+	} `json:"hits"`
+}
+
+// HackerNewsSearchSource finds Hacker News stories through the Algolia API.
+type HackerNewsSearchSource struct {
+	Client *http.Client
+	Query  SearchQuery
+}
+
+// Fetch gets the stories matching the query
+func (s *HackerNewsSearchSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	endpoint := "/search"
+	if s.Query.ByDate {
+		endpoint = "/search_by_date"
+	}
+
+	var results algoliaResponse
+	u := HackerNewsSearchURL + endpoint + "?" + s.Query.values(count).Encode()
+	if err := getJSON(ctx, s.Client, u, &results); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, hit := range results.Hits {
+		comments := HackerNewsItemURL + hit.ObjectID
+		link := hit.URL
+		if link == "" {
+			link = comments
+		}
+
+		news = append(news, Story{
+			Title:       hit.Title,
+			URL:         link,
+			CommentsURL: comments,
+			Score:       hit.Points,
+			HasScore:    true,
+			Comments:    hit.NumComments,
+			Author:      hit.Author,
+			Source:      "hn-search",
+			PublishedAt: time.Unix(hit.CreatedAtI, 0).UTC(),
+		})
+	}
+
+	return truncate(news, count), nil
+}
@@ -0,0 +1,50 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchQueryValues(t *testing.T) {
+	q := SearchQuery{
+		Query:     "rust async",
+		After:     time.Unix(1000, 0),
+		Before:    time.Unix(2000, 0),
+		MinPoints: 100,
+	}
+
+	v := q.values(20)
+	assert.Equal(t, "rust async", v.Get("query"))
+	assert.Equal(t, "story", v.Get("tags"))
+	assert.Equal(t, "20", v.Get("hitsPerPage"))
+	assert.Equal(t, "created_at_i>=1000,created_at_i<2000,points>=100", v.Get("numericFilters"))
+
+	assert.Equal(t, "", SearchQuery{Query: "go"}.values(5).Get("numericFilters"))
+}
+
+func TestHackerNewsSearchFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/search_by_date", r.URL.Path)
+		assert.Equal(t, "golang", r.URL.Query().Get("query"))
+		w.Write([]byte(`{"hits": [
+			{"objectID": "11", "title": "Go 1.11", "url": "https://golang.org", "points": 300, "num_comments": 120, "created_at_i": 1538492645},
+			{"objectID": "12", "title": "Ask HN: Go?", "url": null, "points": 5, "num_comments": 1, "created_at_i": 1538492000}
+		]}`))
+	}))
+	defer done()
+
+	src := &HackerNewsSearchSource{Client: client, Query: SearchQuery{Query: "golang", ByDate: true}}
+	news, err := src.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	assert.Equal(t, "Go 1.11", news[0].Title)
+	assert.Equal(t, 300, news[0].Score)
+	assert.Equal(t, 120, news[0].Comments)
+	assert.Equal(t, HackerNewsItemURL+"11", news[0].CommentsURL)
+	assert.Equal(t, HackerNewsItemURL+"12", news[1].URL)
+}
@@ -0,0 +1,52 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// ArsTechnicaURL is where the Ars Technica feeds live
+const ArsTechnicaURL = "https://feeds.arstechnica.com/arstechnica/"
+
+// arsSections maps the sections to their feed names
+var arsSections = map[string]string{
+	"all":            "index",
+	"apple":          "apple",
+	"business":       "business",
+	"cars":           "cars",
+	"features":       "features",
+	"gadgets":        "gadgets",
+	"gaming":         "gaming",
+	"science":        "science",
+	"security":       "security",
+	"space":          "space",
+	"tech-policy":    "tech-policy",
+	"technology-lab": "technology-lab",
+}
+
+func init() {
+	Register(Source{
+		Name:        "ars",
+		Description: "Latest articles from arstechnica.com, optionally from one section",
+		Flags:       []string{"topic"},
+		New: func(opts Options) Fetcher {
+			return &ArsTechnicaSource{Client: opts.Client, Section: opts.Topic}
+		},
+	})
+}
+
+// ArsTechnicaSource fetches latest stories from https://arstechnica.com/
+type ArsTechnicaSource struct {
+	Client *http.Client
+	// Section such as gadgets or tech-policy, all of them by default
+	Section string
+}
+
+// Fetch gets news from Ars Technica
+func (l *ArsTechnicaSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	feed, err := lookupMode("ars section", l.Section, "all", arsSections)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFeed(ctx, l.Client, ArsTechnicaURL+feed, "ars", count)
+}
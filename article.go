@@ -0,0 +1,127 @@
+package hnreader
+
+import (
+	"context"
+	"image"
+	// formats decoded by FetchImage
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Article is the readable content of a web page.
+type Article struct {
+	Title string
+	// Paragraphs of the text, without markup
+	Paragraphs []string
+	// Image is the address of the picture the page is shared with, from
+	// its og:image or twitter:image meta tag
+	Image string
+}
+
+// Text returns the paragraphs of the article separated by blank lines.
+func (a Article) Text() string {
+	return strings.Join(a.Paragraphs, "\n\n")
+}
+
+// noise selects the parts of a page that are never the article
+const noise = "script, style, noscript, nav, header, footer, aside, form, iframe, svg"
+
+// blocks selects the elements whose text makes up the paragraphs
+const blocks = "p, h1, h2, h3, h4, li, pre, blockquote"
+
+// FetchArticle downloads the page at url and extracts its readable text.
+func FetchArticle(ctx context.Context, client *http.Client, url string) (Article, error) {
+	resp, err := get(ctx, client, url)
+	if err != nil {
+		return Article{}, err
+	}
+	defer resp.Body.Close()
+
+	article, err := ExtractArticle(resp.Body)
+	if err != nil {
+		return article, err
+	}
+	// the image may be given relative to the page, after any redirect
+	if article.Image != "" {
+		if image, err := resp.Request.URL.Parse(article.Image); err == nil {
+			article.Image = image.String()
+		}
+	}
+	return article, nil
+}
+
+// FetchImage downloads the GIF, JPEG or PNG image at url and decodes it.
+func FetchImage(ctx context.Context, client *http.Client, url string) (image.Image, error) {
+	resp, err := get(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	img, _, err := image.Decode(resp.Body)
+	return img, err
+}
+
+// ExtractArticle finds the main text of an HTML page the way readability
+// tools do: the page's <article> or <main> when it has one, otherwise the
+// element holding the most paragraph text.
+func ExtractArticle(r io.Reader) (Article, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Article{}, err
+	}
+	doc.Find(noise).Remove()
+
+	article := Article{Title: strings.TrimSpace(doc.Find("title").First().Text())}
+	article.Image, _ = doc.Find(`meta[property="og:image"], meta[name="twitter:image"]`).First().Attr("content")
+	article.Image = strings.TrimSpace(article.Image)
+	if h1 := strings.TrimSpace(doc.Find("h1").First().Text()); h1 != "" {
+		article.Title = h1
+	}
+
+	content := doc.Find("article").First()
+	if content.Length() == 0 {
+		content = doc.Find("main, [role=main]").First()
+	}
+	if content.Length() == 0 {
+		content = densestNode(doc)
+	}
+
+	content.Find(blocks).Each(func(i int, s *goquery.Selection) {
+		// the text of nested blocks is taken from the outermost one
+		if s.ParentsFiltered(blocks).Length() > 0 {
+			return
+		}
+		if text := strings.Join(strings.Fields(s.Text()), " "); text != "" {
+			article.Paragraphs = append(article.Paragraphs, text)
+		}
+	})
+	return article, nil
+}
+
+// densestNode returns the element whose paragraphs hold the most text, or
+// the body when the page has no paragraphs
+func densestNode(doc *goquery.Document) *goquery.Selection {
+	best := doc.Find("body").First()
+	scores := make(map[*html.Node]int)
+	bestScore := 0
+	doc.Find("p").Each(func(i int, p *goquery.Selection) {
+		parent := p.Parent()
+		if parent.Length() == 0 {
+			return
+		}
+		node := parent.Get(0)
+		scores[node] += len(strings.TrimSpace(p.Text()))
+		if scores[node] > bestScore {
+			best, bestScore = parent, scores[node]
+		}
+	})
+	return best
+}
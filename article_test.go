@@ -0,0 +1,59 @@
+package hnreader
+
+import (
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractArticle(t *testing.T) {
+	article, err := ExtractArticle(strings.NewReader(`<html><head><title>Page</title><script>var x;</script></head><body>
+<nav><p>Home</p><p>About</p></nav>
+<article><h1>The story</h1><p>First   paragraph
+of text.</p><ul><li>A <p>nested</p> point</li></ul><p></p></article>
+<footer><p>Copyright</p></footer>
+</body></html>`))
+	assert.Nil(t, err)
+	assert.Equal(t, "The story", article.Title)
+	assert.Equal(t, []string{"The story", "First paragraph of text.", "A nested point"}, article.Paragraphs)
+}
+
+func TestExtractArticleDensest(t *testing.T) {
+	article, err := ExtractArticle(strings.NewReader(`<html><head><title>Page</title></head><body>
+<div class="sidebar"><p>Short</p></div>
+<div class="post"><p>A much longer paragraph of the post.</p><p>And another one.</p></div>
+</body></html>`))
+	assert.Nil(t, err)
+	assert.Equal(t, "Page", article.Title)
+	assert.Equal(t, "A much longer paragraph of the post.\n\nAnd another one.", article.Text())
+}
+
+func TestFetchArticle(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cover.png" {
+			png.Encode(w, image.NewGray(image.Rect(0, 0, 4, 2)))
+			return
+		}
+		w.Write([]byte(`<head><meta property="og:image" content="/cover.png"></head><main><p>Hello</p></main>`))
+	}))
+	defer done()
+
+	article, err := FetchArticle(context.Background(), client, "https://example.com/post")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Hello"}, article.Paragraphs)
+	// resolved against the page, as it was served by the test server
+	assert.True(t, strings.HasSuffix(article.Image, "/cover.png"), article.Image)
+	assert.True(t, strings.HasPrefix(article.Image, "http"), article.Image)
+
+	img, err := FetchImage(context.Background(), client, article.Image)
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 2), img.Bounds())
+
+	_, err = FetchImage(context.Background(), client, "https://example.com/post")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,48 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ArxivURL is where the arXiv listing feeds live
+const ArxivURL = "https://rss.arxiv.org/rss/"
+
+// DefaultArxivCategories are read when no category is given
+var DefaultArxivCategories = []string{"cs"}
+
+func init() {
+	Register(Source{
+		Name:        "arxiv",
+		Description: "Newest papers of arXiv categories such as cs.LG",
+		Flags:       []string{"category"},
+		New: func(opts Options) Fetcher {
+			return &ArxivSource{Client: opts.Client, Categories: opts.Categories}
+		},
+	})
+}
+
+// ArxivSource fetches the newest papers of https://arxiv.org/ categories,
+// opening their abstract pages
+type ArxivSource struct {
+	Client *http.Client
+	// Categories such as cs.LG or cs.DC, DefaultArxivCategories when empty
+	Categories []string
+}
+
+// Fetch gets the newest papers of all categories in one feed
+func (l *ArxivSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	categories := l.Categories
+	if len(categories) == 0 {
+		categories = DefaultArxivCategories
+	}
+
+	escaped := make([]string, len(categories))
+	for i, category := range categories {
+		escaped[i] = url.PathEscape(category)
+	}
+	// arXiv merges categories joined with a plus into a single feed
+	return fetchFeed(ctx, l.Client, ArxivURL+strings.Join(escaped, "+"), "arxiv", count)
+}
@@ -0,0 +1,111 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// feedTypes ranks the feed formats announced by web pages, best first
+var feedTypes = []string{
+	"application/atom+xml",
+	"application/rss+xml",
+	"application/feed+json",
+	"application/json",
+}
+
+func init() {
+	Register(Source{
+		Name:         "auto",
+		Description:  "The feed announced by the web page given with --url",
+		Flags:        []string{"url"},
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &AutoSource{Client: opts.Client, URL: opts.URL}
+		},
+	})
+}
+
+// AutoSource finds the feed of a web site through the <link rel="alternate">
+// tags of its page and reads it
+type AutoSource struct {
+	Client *http.Client
+	// URL of the page, usually the homepage of a blog
+	URL string
+}
+
+// Fetch discovers the feed and gets its stories
+func (l *AutoSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.URL == "" {
+		return nil, errors.New("the auto source needs the URL of a web page")
+	}
+
+	feed, err := discoverFeed(ctx, l.Client, l.URL)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFeed(ctx, l.Client, feed, "auto", count)
+}
+
+// discoverFeed returns the URL of the best feed the page at pageURL links to.
+// Comment feeds are only used when there is nothing else.
+func discoverFeed(ctx context.Context, client *http.Client, pageURL string) (string, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := get(ctx, client, pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	best, bestRank := "", -1
+	doc.Find(`link[rel~="alternate"]`).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		kind, _ := s.Attr("type")
+		title, _ := s.Attr("title")
+
+		rank := feedRank(kind)
+		if rank < 0 || href == "" {
+			return
+		}
+		if !strings.Contains(strings.ToLower(title), "comment") {
+			rank += len(feedTypes)
+		}
+		if rank <= bestRank {
+			return
+		}
+		if link, err := base.Parse(href); err == nil {
+			best, bestRank = link.String(), rank
+		}
+	})
+
+	if best == "" {
+		return "", fmt.Errorf("%s: no feed found", pageURL)
+	}
+	return best, nil
+}
+
+// feedRank scores a feed content type, higher is better and -1 isn't a feed
+func feedRank(kind string) int {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	for i, feedType := range feedTypes {
+		if kind == feedType {
+			return len(feedTypes) - 1 - i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,57 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const blogPage = `<html><head>
+<link rel="alternate" type="application/rss+xml" title="Comments" href="/comments/feed">
+<link rel="stylesheet" href="/style.css">
+<link rel="alternate" type="application/rss+xml" title="Posts" href="/feed.xml">
+<link rel="alternate" type="application/feed+json" title="Posts" href="/feed.json">
+<link rel="alternate" hreflang="fr" href="/fr/">
+</head><body></body></html>`
+
+func TestDiscoverFeed(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/empty" {
+			w.Write([]byte(`<html><head></head></html>`))
+			return
+		}
+		w.Write([]byte(blogPage))
+	}))
+	defer done()
+
+	feed, err := discoverFeed(context.Background(), client, "https://blog.example.com/")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://blog.example.com/feed.xml", feed)
+
+	_, err = discoverFeed(context.Background(), client, "https://blog.example.com/empty")
+	assert.NotNil(t, err)
+}
+
+func TestAutoSourceFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/feed.xml" {
+			w.Write([]byte(rssFeed))
+			return
+		}
+		w.Write([]byte(blogPage))
+	}))
+	defer done()
+
+	news, err := (&AutoSource{Client: client, URL: "https://blog.example.com"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "auto", news[0].Source)
+}
+
+func TestFeedRank(t *testing.T) {
+	assert.True(t, feedRank("application/atom+xml") > feedRank("application/rss+xml"))
+	assert.True(t, feedRank("application/rss+xml") > feedRank("application/feed+json"))
+	assert.Equal(t, -1, feedRank("text/html"))
+}
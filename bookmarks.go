@@ -0,0 +1,55 @@
+package hnreader
+
+import (
+	"path/filepath"
+)
+
+// BookmarksFile returns the path of bookmarks.json inside ConfigDir.
+func BookmarksFile() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+// LoadBookmarks reads the stories bookmarked in the file at path. A missing
+// file has no bookmarks.
+func LoadBookmarks(path string) ([]Story, error) {
+	return LoadStories(path)
+}
+
+// AddBookmark adds the story to the bookmarks kept in the file at path,
+// unless it is bookmarked already.
+func AddBookmark(path string, story Story) error {
+	news, err := LoadBookmarks(path)
+	if err != nil {
+		return err
+	}
+	for _, saved := range news {
+		if saved.URL == story.URL {
+			return nil
+		}
+	}
+	return SaveStories(path, append(news, story))
+}
+
+// RemoveBookmarks removes the stories from the bookmarks kept in the file at
+// path, going by their URL.
+func RemoveBookmarks(path string, stories ...Story) error {
+	news, err := LoadBookmarks(path)
+	if err != nil {
+		return err
+	}
+	removed := make(map[string]bool, len(stories))
+	for _, story := range stories {
+		removed[story.URL] = true
+	}
+	var kept []Story
+	for _, saved := range news {
+		if !removed[saved.URL] {
+			kept = append(kept, saved)
+		}
+	}
+	return SaveStories(path, kept)
+}
@@ -0,0 +1,37 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "bookmarks.json")
+
+	news, err := LoadBookmarks(path)
+	assert.Nil(t, err)
+	assert.Empty(t, news)
+
+	first := Story{Title: "First", URL: "https://example.com/1", Source: "hn"}
+	assert.Nil(t, AddBookmark(path, first))
+	assert.Nil(t, AddBookmark(path, Story{Title: "Second", URL: "https://example.com/2"}))
+	assert.Nil(t, AddBookmark(path, first))
+
+	news, err = LoadBookmarks(path)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, first.Title, news[0].Title)
+	assert.Equal(t, first.Source, news[0].Source)
+
+	assert.Nil(t, RemoveBookmarks(path, first, Story{URL: "https://example.com/missing"}))
+	news, err = LoadBookmarks(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []Story{{Title: "Second", URL: "https://example.com/2"}}, news)
+}
@@ -0,0 +1,139 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/skratchdot/open-golang/open"
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+)
+
+// Supported operating systems (GOOS)
+const (
+	OSDarwin  = "darwin"
+	OSLinux   = "linux"
+	OSWindows = "windows"
+)
+
+// RunApp opens a browser with input tabs count. Stories fetched before ctx
+// is done, or before the fetch failed, are still opened and the error of
+// the fetch is returned after them.
+func RunApp(ctx context.Context, tabs int, browser string, src Fetcher) error {
+	news, err := src.Fetch(ctx, tabs)
+	if openErr := OpenStories(news, tabs, browser); openErr != nil {
+		return openErr
+	}
+	return err
+}
+
+// OpenStories opens up to tabs of the stories in the given browser, or in
+// the default one when browser is empty or can't be found. It stops at the
+// first story that can't be opened.
+func OpenStories(news []Story, tabs int, browser string) error {
+	browser = findBrowser(browser)
+
+	for i, story := range news {
+		if i == tabs {
+			break
+		}
+
+		if err := openWith(story.URL, browser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open opens a URL or a local file in the given browser, or in the default
+// one when browser is empty or can't be found.
+func Open(target, browser string) error {
+	return openWith(target, findBrowser(browser))
+}
+
+// openWith opens target with a browser already resolved by findBrowser
+func openWith(target, browser string) error {
+	if browser == "" {
+		return open.Run(target)
+	}
+	if err := open.RunWith(target, browser); err != nil {
+		fmt.Printf(red("%s is not found on this computer, trying default browser...\n"), browser)
+		return open.Run(target)
+	}
+	return nil
+}
+
+// findBrowser
+func findBrowser(target string) string {
+	if target == "" {
+		return ""
+	}
+	browsers := []string{"google", "chrome", "mozilla", "firefox", "brave"}
+	shortest := -1
+	word := ""
+	for _, browser := range browsers {
+		distance := levenshtein.DistanceForStrings([]rune(browser), []rune(target), levenshtein.DefaultOptions)
+		if distance == 0 {
+			word = browser
+			break
+		}
+		if distance <= shortest || shortest < 0 {
+			shortest = distance
+			word = browser
+		}
+	}
+
+	return getBrowserNameByOS(word, runtime.GOOS)
+}
+
+// getGoogleChromeNameForOS
+func getGoogleChromeNameForOS(os string) string {
+	switch os {
+	case OSDarwin:
+		return "Google Chrome"
+	case OSLinux:
+		return "google-chrome"
+	case OSWindows:
+		return "chrome"
+	}
+	return ""
+}
+
+// getFirefoxNameForOS
+func getFirefoxNameForOS(os string) string {
+	switch os {
+	case OSDarwin:
+		return "Firefox"
+	case OSLinux:
+		return "firefox"
+	case OSWindows:
+		return "firefox"
+	}
+	return ""
+}
+
+// getBraveNameForOS
+func getBraveNameForOS(os string) string {
+	switch os {
+	case OSDarwin:
+		return "Brave"
+	case OSLinux:
+		return "brave"
+	case OSWindows:
+		return "brave"
+	}
+	return ""
+}
+
+// getBrowserNameByOS normilizes browser name
+func getBrowserNameByOS(browserFromCLI, os string) string {
+	switch browserFromCLI {
+	case "google", "chrome":
+		return getGoogleChromeNameForOS(os)
+	case "mozilla", "firefox":
+		return getFirefoxNameForOS(os)
+	case "brave":
+		return getBraveNameForOS(os)
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package hnreader
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+)
+
+// CacheDir returns the directory hnreader caches responses in, following the
+// XDG base directory spec on Linux ($XDG_CACHE_HOME/hnreader).
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// LastListingFile returns the path of last.json inside CacheDir, which
+// keeps the stories most recently listed.
+func LastListingFile() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "last.json"), nil
+}
+
+// CacheTransport stores successful GET responses on disk and revalidates them
+// with If-None-Match and If-Modified-Since, so unchanged feeds are served
+// from the cache after a 304 Not Modified.
+type CacheTransport struct {
+	// Dir holds one file per cached URL
+	Dir string
+	// Transport makes the actual requests, http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if req.Method != http.MethodGet {
+		return transport.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	cached := t.load(path, req)
+	if cached != nil {
+		// never modify the caller's request
+		r := new(http.Request)
+		*r = *req
+		r.Header = cloneHeader(req.Header)
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			r.Header.Set("If-Modified-Since", modified)
+		}
+		req = r
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		if cached != nil {
+			cached.Body.Close()
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		cached.Request = req
+		return cached, nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+
+	if resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+		t.store(path, resp)
+	}
+
+	return resp, nil
+}
+
+// path returns the cache file for the request URL
+func (t *CacheTransport) path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.String()))
+	return filepath.Join(t.Dir, hex.EncodeToString(sum[:]))
+}
+
+// load reads a cached response, returning nil when there is none
+func (t *CacheTransport) load(path string, req *http.Request) *http.Response {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil
+	}
+	return resp
+}
+
+// store saves resp to path while leaving its body readable for the caller.
+// Failing to write the cache is not an error, the next run just refetches.
+func (t *CacheTransport) store(path string, resp *http.Response) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	copied := *resp
+	copied.Body = ioutil.NopCloser(bytes.NewReader(body))
+	copied.ContentLength = int64(len(body))
+	copied.TransferEncoding = nil
+	dump, err := httputil.DumpResponse(&copied, true)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(t.Dir, 0700); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(t.Dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(dump)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	os.Rename(tmp.Name(), path)
+}
+
+// cloneHeader returns a deep copy of h
+func cloneHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, v := range h {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	hits, revalidated := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("feed body"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &CacheTransport{Dir: dir}}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL + "/feed")
+		assert.Nil(t, err)
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "feed body", string(body))
+	}
+
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 2, revalidated)
+}
+
+func TestCacheTransportSkipsUnvalidated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("no validators"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &CacheTransport{Dir: dir}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,56 @@
+package hnreader
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams are the query parameters added to links to track where
+// readers come from, which don't change the page
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"mkt_tok": true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+	"ref":     true,
+	"ref_src": true,
+	"ref_url": true,
+}
+
+// CanonicalURL normalizes the address of a web page so that the links
+// different sites give to the same page are equal: the scheme is https,
+// the host is lowercased without www. and default ports, tracking
+// parameters, the fragment and trailing slashes are dropped and the query
+// is sorted. Addresses that aren't http or https are returned as they are.
+func CanonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return raw
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for name := range query {
+		if trackingParams[strings.ToLower(name)] || strings.HasPrefix(strings.ToLower(name), "utm_") {
+			query.Del(name)
+		}
+	}
+
+	canonical := url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     strings.TrimRight(u.Path, "/"),
+		RawQuery: query.Encode(),
+	}
+	return canonical.String()
+}
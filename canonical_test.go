@@ -0,0 +1,22 @@
+package hnreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	for raw, canonical := range map[string]string{
+		"https://example.com/post":                                     "https://example.com/post",
+		"http://WWW.Example.com/post/":                                 "https://example.com/post",
+		"https://example.com:443/post#comments":                        "https://example.com/post",
+		"https://example.com/post?utm_source=hn&utm_medium=x&id=2&a=1": "https://example.com/post?a=1&id=2",
+		"https://example.com/?ref=lobsters&fbclid=abc":                 "https://example.com",
+		"http://localhost:8080/post":                                   "https://localhost:8080/post",
+		"mailto:someone@example.com":                                   "mailto:someone@example.com",
+		"not a url":                                                    "not a url",
+	} {
+		assert.Equal(t, canonical, CanonicalURL(raw), raw)
+	}
+}
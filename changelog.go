@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// ChangelogURL is the Changelog News feed
+const ChangelogURL = "https://changelog.com/news/feed"
+
+func init() {
+	Register(Source{
+		Name:        "changelog",
+		Description: "Curated links from Changelog News",
+		New: func(opts Options) Fetcher {
+			return &ChangelogSource{Client: opts.Client}
+		},
+	})
+}
+
+// ChangelogSource fetches latest stories from https://changelog.com/news
+type ChangelogSource struct {
+	Client *http.Client
+}
+
+// Fetch gets news from Changelog
+func (l *ChangelogSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return fetchFeed(ctx, l.Client, ChangelogURL, "changelog", count)
+}
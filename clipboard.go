@@ -0,0 +1,44 @@
+package hnreader
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// getClipboardCommandsForOS lists the commands copying their standard input
+// to the clipboard, in order of preference
+func getClipboardCommandsForOS(os string, wayland bool) [][]string {
+	switch os {
+	case OSDarwin:
+		return [][]string{{"pbcopy"}}
+	case OSWindows:
+		return [][]string{{"clip"}}
+	}
+
+	commands := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if wayland {
+		commands = append([][]string{{"wl-copy"}}, commands...)
+	}
+	return commands
+}
+
+// CopyToClipboard puts text on the system clipboard, through the first
+// clipboard command found on this computer
+func CopyToClipboard(text string) error {
+	for _, args := range getClipboardCommandsForOS(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "") {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New("no clipboard command found, install xclip, xsel or wl-clipboard")
+}
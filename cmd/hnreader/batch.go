@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+)
+
+// openBatches opens the stories size at a time, calling wait between two
+// batches. Opening stops when wait fails.
+func openBatches(news []hnreader.Story, size int, open func(story hnreader.Story) error, wait func(opened, left int) error) error {
+	for start := 0; start < len(news); start += size {
+		if start > 0 {
+			if err := wait(start, len(news)-start); err != nil {
+				return err
+			}
+		}
+
+		end := start + size
+		if end > len(news) {
+			end = len(news)
+		}
+		for _, story := range news[start:end] {
+			if err := open(story); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// waitForEnter returns a wait function for openBatches that asks the user
+// to press Enter on in, or just pauses for delay when it isn't zero
+func waitForEnter(in io.Reader, size int, delay time.Duration) func(opened, left int) error {
+	lines := bufio.NewReader(in)
+	return func(opened, left int) error {
+		next := size
+		if left < next {
+			next = left
+		}
+		if delay > 0 {
+			fmt.Printf("Opened %d stories, opening the next %d in %s...\n", opened, next, delay)
+			time.Sleep(delay)
+			return nil
+		}
+
+		fmt.Printf("Opened %d stories, press Enter for the next %d...", opened, next)
+		_, err := lines.ReadString('\n')
+		if err == io.EOF {
+			return fmt.Errorf("no input left to wait on, %d stories not opened (see --batch-delay)", left)
+		}
+		return err
+	}
+}
+
+// runBatches opens the stories in batches of size, recording each batch in
+// the history before waiting for the next one
+func runBatches(news []hnreader.Story, size int, browser string, delay time.Duration) error {
+	var opened []hnreader.Story
+	record := func() {
+		recordOpened(opened)
+		opened = nil
+	}
+	defer record()
+
+	wait := waitForEnter(os.Stdin, size, delay)
+	return openBatches(news, size, func(story hnreader.Story) error {
+		if err := openStory(story, browser); err != nil {
+			return err
+		}
+		opened = append(opened, story)
+		return nil
+	}, func(done, left int) error {
+		record()
+		return wait(done, left)
+	})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Bunchhieng/hnreader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBatches(t *testing.T) {
+	news := make([]hnreader.Story, 7)
+	for i := range news {
+		news[i].URL = string('a' + rune(i))
+	}
+
+	var opened []string
+	var waits [][2]int
+	err := openBatches(news, 3, func(story hnreader.Story) error {
+		opened = append(opened, story.URL)
+		return nil
+	}, func(done, left int) error {
+		waits = append(waits, [2]int{done, left})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "abcdefg", strings.Join(opened, ""))
+	assert.Equal(t, [][2]int{{3, 4}, {6, 1}}, waits)
+
+	// nothing more is opened once waiting fails
+	opened = nil
+	err = openBatches(news, 3, func(story hnreader.Story) error {
+		opened = append(opened, story.URL)
+		return nil
+	}, func(done, left int) error {
+		return errors.New("stop")
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, opened, 3)
+}
+
+func TestWaitForEnter(t *testing.T) {
+	wait := waitForEnter(strings.NewReader("\n"), 5, 0)
+	assert.Nil(t, wait(5, 10))
+	assert.NotNil(t, wait(10, 5))
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getBookmarkCommands return the subcommands of the bookmark command
+func getBookmarkCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Bookmark stories of the last listing by their numbers, or pages by their URL",
+			ArgsUsage: "<number|first-last|URL>...",
+			Flags:     getNetworkFlags(),
+			Action:    bookmarkAddAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "Print the bookmarks",
+			Flags:  getOutputFlags(),
+			Action: bookmarkListAction,
+		},
+		{
+			Name:      "open",
+			Usage:     "Open the bookmarks, or some of them by their numbers",
+			ArgsUsage: "[number|first-last]...",
+			Flags:     getOpenFlags(),
+			Action:    bookmarkOpenAction,
+		},
+		{
+			Name:      "remove",
+			Aliases:   []string{"rm"},
+			Usage:     "Remove bookmarks by their numbers",
+			ArgsUsage: "<number|first-last>...",
+			Action:    bookmarkRemoveAction,
+		},
+	}
+}
+
+// isURL tells whether the argument is a page to bookmark rather than story
+// numbers
+func isURL(arg string) bool {
+	return strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://")
+}
+
+// bookmarkAddAction bookmarks stories of the last listing and pages
+func bookmarkAddAction(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return handleError(errors.New("bookmark add needs story numbers or URLs, e.g. hnreader bookmark add 3 https://example.com"))
+	}
+	path, err := hnreader.BookmarksFile()
+	if err != nil {
+		return handleError(err)
+	}
+	stories, err := argStories(c)
+	if err != nil {
+		return handleError(err)
+	}
+
+	for _, story := range stories {
+		if err := hnreader.AddBookmark(path, story); err != nil {
+			return handleError(err)
+		}
+		fmt.Printf("%s %s\n", blue("Bookmarked"), storyTitle(story))
+	}
+	return nil
+}
+
+// argStories returns the stories given as arguments, by their numbers in
+// the last listing or by URL
+func argStories(c *cli.Context) ([]hnreader.Story, error) {
+	var numbers, urls []string
+	for _, arg := range c.Args().Slice() {
+		if isURL(arg) {
+			urls = append(urls, arg)
+		} else {
+			numbers = append(numbers, arg)
+		}
+	}
+
+	var stories []hnreader.Story
+	if len(numbers) > 0 {
+		last, err := hnreader.LastListingFile()
+		if err != nil {
+			return nil, err
+		}
+		news, err := hnreader.LoadStories(last)
+		if err != nil {
+			return nil, err
+		}
+		if len(news) == 0 {
+			return nil, errors.New("no stories listed yet, run hnreader list first")
+		}
+		if stories, err = pickStories(news, numbers); err != nil {
+			return nil, err
+		}
+	}
+	if len(urls) > 0 {
+		client, err := newClient(c)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := newContext(c)
+		defer cancel()
+		for _, url := range urls {
+			stories = append(stories, pageStory(ctx, client, url))
+		}
+	}
+	return stories, nil
+}
+
+// pageStory returns the page at url as a story, titled after the page when
+// it can be fetched
+func pageStory(ctx context.Context, client *http.Client, url string) hnreader.Story {
+	story := hnreader.Story{Title: url, URL: url, PublishedAt: time.Now()}
+	if article, err := hnreader.FetchArticle(ctx, client, url); err == nil && article.Title != "" {
+		story.Title = article.Title
+	}
+	return story
+}
+
+// loadBookmarks reads the bookmarks, failing when there are none
+func loadBookmarks() (string, []hnreader.Story, error) {
+	path, err := hnreader.BookmarksFile()
+	if err != nil {
+		return "", nil, err
+	}
+	news, err := hnreader.LoadBookmarks(path)
+	if err == nil && len(news) == 0 {
+		err = errors.New("no bookmarks yet, add some with hnreader bookmark add")
+	}
+	return path, news, err
+}
+
+// bookmarkListAction prints the bookmarks, numbered for open and remove
+func bookmarkListAction(c *cli.Context) error {
+	_, news, err := loadBookmarks()
+	if err != nil {
+		return handleError(err)
+	}
+	return handleError(writeOutput(c, news))
+}
+
+// bookmarkOpenAction opens every bookmark or the ones given by numbers
+func bookmarkOpenAction(c *cli.Context) error {
+	_, news, err := loadBookmarks()
+	if err != nil {
+		return handleError(err)
+	}
+	if c.Args().Len() > 0 {
+		if news, err = pickStories(news, c.Args().Slice()); err != nil {
+			return handleError(err)
+		}
+	}
+	return handleError(openTabs(c, pagesToOpen(news, c.Bool("comments"), c.Bool("both"))))
+}
+
+// bookmarkRemoveAction removes the bookmarks given by numbers
+func bookmarkRemoveAction(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return handleError(errors.New("bookmark remove needs the numbers of the bookmarks, e.g. hnreader bookmark remove 2 4-5"))
+	}
+	path, news, err := loadBookmarks()
+	if err != nil {
+		return handleError(err)
+	}
+	picked, err := pickStories(news, c.Args().Slice())
+	if err != nil {
+		return handleError(err)
+	}
+	return handleError(hnreader.RemoveBookmarks(path, picked...))
+}
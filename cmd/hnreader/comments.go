@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Bunchhieng/hnreader"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// commentsHelp is the key reminder shown under the discussion
+const commentsHelp = "↑/↓ comment • space collapse • pgup/pgdn page • o open story • q quit"
+
+// getCommentsFlags return the flags of the comments command
+func getCommentsFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "print",
+			Usage: "Print the whole discussion instead of browsing it\t",
+		},
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+	}
+	return append(flags, getNetworkFlags()...)
+}
+
+// commentsAction shows the discussion of a Hacker News story
+func commentsAction(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return handleError(errors.New("comments needs a story ID or URL, e.g. hnreader comments 18103645"))
+	}
+	id, err := hnreader.ParseItemID(c.Args().First())
+	if err != nil {
+		return handleError(err)
+	}
+
+	client, err := newClient(c)
+	if err != nil {
+		return handleError(err)
+	}
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	d, err := hnreader.FetchDiscussion(ctx, client, id)
+	if err != nil {
+		return handleError(err)
+	}
+
+	m := newCommentsModel(d)
+	if c.Bool("print") || !term.IsTerminal(int(os.Stdout.Fd())) {
+		width := 80
+		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			width = w
+		}
+		m.cursor = -1
+		lines, _ := m.render(width)
+		return handleError(page([]byte(strings.Join(lines, "\n") + "\n")))
+	}
+
+	browser := c.String("browser")
+	m.open = func(url string) error {
+		return hnreader.Open(url, browser)
+	}
+	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return handleError(err)
+}
+
+// commentEntry is a comment at its depth in the thread
+type commentEntry struct {
+	comment *hnreader.Comment
+	depth   int
+}
+
+// commentsModel is the state of the discussion viewer
+type commentsModel struct {
+	discussion *hnreader.Discussion
+	// entries are all the comments in thread order
+	entries []commentEntry
+	// collapsed are the IDs of the comments whose replies are hidden
+	collapsed map[int]bool
+	// cursor is the position in visible() of the current comment, or -1
+	// for none. offset is the first line shown.
+	cursor, offset int
+	width, height  int
+	status         string
+
+	open func(url string) error
+}
+
+// newCommentsModel returns a viewer of the discussion, every comment shown
+func newCommentsModel(d *hnreader.Discussion) *commentsModel {
+	m := &commentsModel{discussion: d, collapsed: make(map[int]bool)}
+	var walk func(comments []hnreader.Comment, depth int)
+	walk = func(comments []hnreader.Comment, depth int) {
+		for i := range comments {
+			m.entries = append(m.entries, commentEntry{&comments[i], depth})
+			walk(comments[i].Replies, depth+1)
+		}
+	}
+	walk(d.Comments, 0)
+	return m
+}
+
+// visible returns the comments not hidden by a collapsed parent
+func (m *commentsModel) visible() []commentEntry {
+	var entries []commentEntry
+	hidden := -1
+	for _, entry := range m.entries {
+		if hidden >= 0 {
+			if entry.depth > hidden {
+				continue
+			}
+			hidden = -1
+		}
+		entries = append(entries, entry)
+		if m.collapsed[entry.comment.ID] {
+			hidden = entry.depth
+		}
+	}
+	return entries
+}
+
+// Init implements tea.Model
+func (m *commentsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *commentsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.follow()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			m.move(-1)
+		case "down", "j":
+			m.move(1)
+		case "home", "g":
+			m.move(-len(m.entries))
+			m.offset = 0
+		case "end", "G":
+			m.move(len(m.entries))
+		case "pgup":
+			m.scrollPage(-1)
+		case "pgdown":
+			m.scrollPage(1)
+		case " ", "enter":
+			if visible := m.visible(); len(visible) > 0 {
+				id := visible[m.cursor].comment.ID
+				m.collapsed[id] = !m.collapsed[id]
+				m.follow()
+			}
+		case "o":
+			if err := m.open(m.discussion.Story.URL); err != nil {
+				m.status = err.Error()
+			}
+		}
+	}
+	return m, nil
+}
+
+// move moves the cursor by delta comments and scrolls to it
+func (m *commentsModel) move(delta int) {
+	m.cursor += delta
+	if last := len(m.visible()) - 1; m.cursor > last {
+		m.cursor = last
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.follow()
+}
+
+// rows returns how many lines fit above the footer
+func (m *commentsModel) rows() int {
+	if m.height > 2 {
+		return m.height - 2
+	}
+	return 1
+}
+
+// follow scrolls so that the first line of the current comment is shown
+func (m *commentsModel) follow() {
+	_, starts := m.render(m.width)
+	if m.cursor < 0 || m.cursor >= len(starts) || m.height == 0 {
+		return
+	}
+	start := starts[m.cursor]
+	if start < m.offset || start >= m.offset+m.rows() {
+		m.offset = start
+	}
+}
+
+// scrollPage scrolls a page up or down, moving the cursor to the first
+// comment starting on it
+func (m *commentsModel) scrollPage(direction int) {
+	lines, starts := m.render(m.width)
+	m.offset += direction * m.rows()
+	if m.offset > len(lines)-m.rows() {
+		m.offset = len(lines) - m.rows()
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+	for i, start := range starts {
+		if start >= m.offset {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// render returns the lines of the discussion wrapped to width, and the
+// line each visible comment starts on
+func (m *commentsModel) render(width int) ([]string, []int) {
+	if width <= 0 {
+		width = 80
+	}
+	story := m.discussion.Story
+	lines := []string{yellow(story.Title), blue(fmt.Sprintf("%d points, %d comments", story.Score, story.Comments)), story.URL, ""}
+	if m.discussion.Text != "" {
+		lines = append(lines, wrapParagraphs(m.discussion.Text, width, "")...)
+		lines = append(lines, "")
+	}
+
+	var starts []int
+	for i, entry := range m.visible() {
+		starts = append(starts, len(lines))
+		indent := strings.Repeat("  ", entry.depth)
+		marker := "  "
+		if i == m.cursor {
+			marker = yellow("> ")
+		}
+
+		author := entry.comment.Author
+		if author == "" {
+			author = "[deleted]"
+		}
+		header := indent + marker + blue(author)
+		if m.collapsed[entry.comment.ID] {
+			header += fmt.Sprintf(" [+%d]", entry.comment.Count())
+		}
+		lines = append(lines, header)
+		if !m.collapsed[entry.comment.ID] {
+			lines = append(lines, wrapParagraphs(entry.comment.Text, width-len(indent)-2, indent+"  ")...)
+		}
+		lines = append(lines, "")
+	}
+	return lines, starts
+}
+
+// wrapParagraphs wraps each paragraph of text to width and indents the lines
+func wrapParagraphs(text string, width int, indent string) []string {
+	if width < 20 {
+		width = 20
+	}
+	var lines []string
+	for i, paragraph := range strings.Split(text, "\n\n") {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		for _, line := range wrap(paragraph, width) {
+			lines = append(lines, indent+line)
+		}
+	}
+	return lines
+}
+
+// View implements tea.Model
+func (m *commentsModel) View() string {
+	lines, _ := m.render(m.width)
+	start := m.offset
+	if start > len(lines) {
+		start = len(lines)
+	}
+	end := start + m.rows()
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[start:end], "\n") + "\n" + m.status + "\n" + commentsHelp
+}
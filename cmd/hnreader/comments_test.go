@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Bunchhieng/hnreader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDiscussion() *hnreader.Discussion {
+	return &hnreader.Discussion{
+		Story: hnreader.Story{Title: "A story", URL: "https://example.com/story", Score: 10, Comments: 4},
+		Comments: []hnreader.Comment{
+			{ID: 1, Author: "dang", Text: "First", Replies: []hnreader.Comment{
+				{ID: 2, Author: "pg", Text: "Reply", Replies: []hnreader.Comment{{ID: 3, Text: ""}}},
+			}},
+			{ID: 4, Author: "tptacek", Text: "Second"},
+		},
+	}
+}
+
+func TestCommentsModelCollapse(t *testing.T) {
+	m := newCommentsModel(testDiscussion())
+	assert.Len(t, m.visible(), 4)
+
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	visible := m.visible()
+	assert.Len(t, visible, 2)
+	assert.Equal(t, 4, visible[1].comment.ID)
+
+	lines, starts := m.render(80)
+	assert.Len(t, starts, 2)
+	assert.Contains(t, lines[starts[0]], "[+2]")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, m.cursor)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, m.cursor)
+}
+
+func TestCommentsModelRender(t *testing.T) {
+	m := newCommentsModel(testDiscussion())
+	m.cursor = -1
+	lines, starts := m.render(80)
+	text := strings.Join(lines, "\n")
+
+	assert.Contains(t, text, "A story")
+	assert.Contains(t, text, "[deleted]")
+	assert.Len(t, starts, 4)
+	// replies are indented under their parent
+	assert.True(t, strings.HasPrefix(lines[starts[1]+1], "    Reply"))
+}
+
+func TestWrapParagraphs(t *testing.T) {
+	assert.Equal(t, []string{"> one", "", "> two"}, wrapParagraphs("one\n\ntwo", 40, "> "))
+}
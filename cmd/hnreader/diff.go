@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getDiffFlags return the flags of the diff command
+func getDiffFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "number",
+			Value:   30,
+			Aliases: []string{"n"},
+			Usage:   "Specify number of stories compared\t",
+		},
+		&cli.BoolFlag{
+			Name:    "open",
+			Aliases: []string{"o"},
+			Usage:   "Open the new stories instead of printing them\t",
+		},
+	}
+	flags = append(flags, getOpenFlags()...)
+	flags = append(flags, getSourceFlags()...)
+	flags = append(flags, getFilterFlags()...)
+	flags = append(flags, getOutputFlags()...)
+	return append(flags, getNetworkFlags()...)
+}
+
+// diffAction prints or opens the stories that appeared since the last diff
+// of the same listing, and keeps the listing for the next one
+func diffAction(c *cli.Context) error {
+	src, count, err := newSourceFetcher(c, "number")
+	if err != nil {
+		return handleError(err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	news, err := fetchStories(ctx, c, src, count)
+	reportFetchError(c, err)
+
+	dir, err := hnreader.SnapshotsDir()
+	if err != nil {
+		return handleError(err)
+	}
+	path := hnreader.SnapshotFile(dir, snapshotKey(c))
+	previous, err := hnreader.LoadStories(path)
+	if err != nil {
+		return handleError(err)
+	}
+	fresh := hnreader.NewStories(previous, news)
+	// a failed fetch would make every story new next time
+	if len(news) > 0 {
+		if err := hnreader.SaveStories(path, news); err != nil {
+			return handleError(err)
+		}
+	}
+
+	if c.Bool("open") {
+		if len(fresh) == 0 {
+			fmt.Println("Nothing new since the last diff")
+			return nil
+		}
+		return handleError(openTabs(c, pagesToOpen(fresh, c.Bool("comments"), c.Bool("both"))))
+	}
+
+	// kept for the open command, which is fine to go without
+	if path, err := hnreader.LastListingFile(); err == nil {
+		hnreader.SaveStories(path, fresh)
+	}
+	return handleError(writeOutput(c, fresh))
+}
+
+// snapshotKey describes the listing fetched by the source and filter flags,
+// so that each listing is compared with its own snapshot
+func snapshotKey(c *cli.Context) string {
+	key := []string{"source=" + c.String("source")}
+	flags := append(getSourceFlags(), getFilterFlags()...)
+	for _, flag := range flags {
+		name := flag.Names()[0]
+		if name == "source" || !c.IsSet(name) {
+			continue
+		}
+		switch flag.(type) {
+		case *cli.StringFlag:
+			key = append(key, name+"="+c.String(name))
+		case *cli.BoolFlag:
+			key = append(key, fmt.Sprintf("%s=%t", name, c.Bool(name)))
+		case *cli.IntFlag:
+			key = append(key, fmt.Sprintf("%s=%d", name, c.Int(name)))
+		case *cli.UintFlag:
+			key = append(key, fmt.Sprintf("%s=%d", name, c.Uint(name)))
+		case *cli.DurationFlag:
+			key = append(key, name+"="+c.Duration(name).String())
+		}
+	}
+	return strings.Join(key, " ")
+}
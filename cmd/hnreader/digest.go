@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getDigestFlags return the flags of the digest command
+func getDigestFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "number",
+			Value:   30,
+			Aliases: []string{"n"},
+			Usage:   "Specify number of stories\t",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Value: "digest.html",
+			Usage: "Write the page to this file\t",
+		},
+		&cli.StringFlag{
+			Name:  "title",
+			Value: "hnreader digest",
+			Usage: "Title of the page\t",
+		},
+		&cli.BoolFlag{
+			Name:    "open",
+			Aliases: []string{"o"},
+			Usage:   "Open the page in the browser once written\t",
+		},
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+	}
+	flags = append(flags, getSourceFlags()...)
+	flags = append(flags, getFilterFlags()...)
+	return append(flags, getNetworkFlags()...)
+}
+
+// digestAction writes the stories of the chosen sources to a single HTML page
+func digestAction(c *cli.Context) error {
+	src, count, err := newSourceFetcher(c, "number")
+	if err != nil {
+		return handleError(err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	news, err := fetchStories(ctx, c, src, count)
+	handleError(err)
+
+	path, err := filepath.Abs(c.String("out"))
+	if err != nil {
+		return handleError(err)
+	}
+	if err := writeDigest(path, c.String("title"), news); err != nil {
+		return handleError(err)
+	}
+
+	if c.Bool("open") {
+		return handleError(hnreader.Open(path, c.String("browser")))
+	}
+	return nil
+}
+
+// writeDigest renders the stories to the HTML page at path
+func writeDigest(path, title string, news []hnreader.Story) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := hnreader.WriteDigest(f, title, news); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// overfetch is how many times more stories are fetched than needed when
+// filters may leave some of them out, or to shuffle and sample from
+const overfetch = 3
+
+// getFilterFlags return the flags leaving out some of the fetched stories
+// and ordering the others
+func getFilterFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "match",
+			Usage: "Only keep the stories whose title or URL contains any of these comma separated keywords\t",
+		},
+		&cli.StringFlag{
+			Name:  "exclude",
+			Usage: "Leave out the stories whose title or URL contains any of these comma separated keywords, on top of the blocklist of the configuration\t",
+		},
+		&cli.StringFlag{
+			Name:  "match-re",
+			Usage: "Only keep the stories whose title or URL matches this regular expression, like '(?i)\\bgo(lang)?\\b'\t",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-re",
+			Usage: "Leave out the stories whose title or URL matches this regular expression\t",
+		},
+		&cli.StringFlag{
+			Name:  "allow-domains-file",
+			Usage: "Only keep the stories linking to the domains listed one per line in this file, *.example.com including subdomains\t",
+		},
+		&cli.StringFlag{
+			Name:  "deny-domains-file",
+			Usage: "Leave out the stories linking to the domains listed one per line in this file, *.example.com including subdomains\t",
+		},
+		&cli.StringFlag{
+			Name:  "author",
+			Usage: "Only keep the stories submitted by these comma separated users\t",
+		},
+		&cli.StringFlag{
+			Name:  "tagged",
+			Usage: "Only keep the stories with any of these comma separated tags or reddit flairs\t",
+		},
+		&cli.StringFlag{
+			Name:  "language",
+			Usage: "Only keep the stories whose title is in one of these comma separated languages, like en, and those whose language can't be told\t",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-paywalled",
+			Usage: "Leave out the stories likely behind a paywall, from known sites, the paywalls of the configuration or marked in their title\t",
+		},
+		&cli.DurationFlag{
+			Name:  "max-age",
+			Usage: "Leave out the stories published longer ago than this, like 24h, keeping those without a date\t",
+		},
+		&cli.BoolFlag{
+			Name:  "unread-only",
+			Usage: "Leave out the stories opened or marked as read before\t",
+		},
+		&cli.UintFlag{
+			Name:  "max-per-domain",
+			Usage: "Keep at most this many stories linking to the same site\t",
+		},
+		&cli.IntFlag{
+			Name:  "min-score",
+			Usage: "Leave out the stories with fewer points, keeping those of sources without points\t",
+		},
+		// --sort is taken by the order of the reddit listing
+		&cli.StringFlag{
+			Name:  "sort-by",
+			Usage: fmt.Sprintf("Order the stories by this instead of as the sources list them (one of %s)\t", strings.Join(hnreader.SortKeys, ", ")),
+		},
+		&cli.BoolFlag{
+			Name:  "desc",
+			Usage: "Sort the stories in descending order, like the highest scores first\t",
+		},
+		&cli.BoolFlag{
+			Name:  "shuffle",
+			Usage: "Put the stories in a random order, picked among three times as many fetched ones\t",
+		},
+		&cli.UintFlag{
+			Name:  "sample",
+			Usage: "Only keep this many stories, picked at random among three times as many fetched ones\t",
+		},
+	}
+}
+
+// newFilter returns the filter described by the filter flags and the
+// configuration, nil when none of them is set
+func newFilter(c *cli.Context, config *hnreader.Config) (hnreader.Filter, error) {
+	var filters []hnreader.Filter
+	if keywords := splitList(c.String("match")); len(keywords) > 0 {
+		filters = append(filters, hnreader.Matching(keywords))
+	}
+	muted := append(splitList(c.String("exclude")), config.Blocklist.Keywords...)
+	if len(muted) > 0 || len(config.Blocklist.Domains) > 0 {
+		filters = append(filters, hnreader.Excluding(muted, config.Blocklist.Domains))
+	}
+	if c.IsSet("match-re") {
+		re, err := compileFlag(c, "match-re")
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, hnreader.MatchingRegexp(re))
+	}
+	if c.IsSet("exclude-re") {
+		re, err := compileFlag(c, "exclude-re")
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, hnreader.ExcludingRegexp(re))
+	}
+	if path := c.String("allow-domains-file"); path != "" {
+		patterns, err := hnreader.LoadDomains(path)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, hnreader.AllowDomains(patterns))
+	}
+	if path := c.String("deny-domains-file"); path != "" {
+		patterns, err := hnreader.LoadDomains(path)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, hnreader.DenyDomains(patterns))
+	}
+	if authors := splitList(c.String("author")); len(authors) > 0 {
+		filters = append(filters, hnreader.ByAuthors(authors))
+	}
+	if tags := splitList(c.String("tagged")); len(tags) > 0 {
+		filters = append(filters, hnreader.Tagged(tags))
+	}
+	if langs := splitList(c.String("language")); len(langs) > 0 {
+		filters = append(filters, hnreader.InLanguages(langs))
+	}
+	if c.Bool("skip-paywalled") {
+		filters = append(filters, hnreader.SkipPaywalled(config.Paywalls))
+	}
+	if age := c.Duration("max-age"); age > 0 {
+		filters = append(filters, hnreader.MaxAge(age, time.Now()))
+	}
+	if c.IsSet("min-score") {
+		filters = append(filters, hnreader.MinScore(c.Int("min-score")))
+	}
+	if c.Bool("unread-only") {
+		read, err := readURLs()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, hnreader.Unread(read))
+	}
+	// last, to only count the stories the other filters keep
+	if max := c.Int("max-per-domain"); max > 0 {
+		filters = append(filters, hnreader.MaxPerDomain(max))
+	}
+
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return hnreader.AllFilters(filters...), nil
+}
+
+// compileFlag compiles the regular expression given to the flag
+func compileFlag(c *cli.Context, name string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(c.String(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %v", name, err)
+	}
+	return re, nil
+}
+
+// fetchStories gets up to count stories of src that pass the filter flags,
+// fetching more of them to make up for the ones left out, in the order of
+// --sort-by or --shuffle and sampled with --sample, which pick among all
+// of the fetched stories. The stories likely
+// behind a paywall are tagged as such, and all of them are recorded in the
+// history.
+func fetchStories(ctx context.Context, c *cli.Context, src hnreader.Fetcher, count int) ([]hnreader.Story, error) {
+	config, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newFilter(c, config)
+	if err != nil {
+		return nil, err
+	}
+	key := c.String("sort-by")
+	if key != "" {
+		// an unknown key is reported before fetching anything
+		if err := hnreader.SortStories(nil, key, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// shuffled and sampled stories are picked among a larger pool
+	sample, shuffle := c.Int("sample"), c.Bool("shuffle")
+	fetch := count
+	if filter != nil || sample > 0 || shuffle {
+		fetch = count * overfetch
+	}
+	news, err := src.Fetch(ctx, fetch)
+	hnreader.MarkPaywalled(news, config.Paywalls)
+	withHistory(func(h *hnreader.History) error {
+		return h.RecordFetched(news, time.Now())
+	})
+	if filter != nil {
+		news = filter.Apply(news)
+	}
+	if key != "" {
+		hnreader.SortStories(news, key, c.Bool("desc"))
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	if shuffle {
+		hnreader.Shuffle(news, rng)
+	}
+	if sample > 0 {
+		news = hnreader.Sample(news, sample, rng)
+	}
+	if len(news) > count {
+		news = news[:count]
+	}
+	return news, err
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getHistoryFlags return the flags of the history command
+func getHistoryFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "number",
+			Value:   20,
+			Aliases: []string{"n"},
+			Usage:   "Specify number of stories\t",
+		},
+		&cli.BoolFlag{
+			Name:  "opened",
+			Usage: "Only print the stories that were opened\t",
+		},
+	}
+	return append(flags, getOutputFlags()...)
+}
+
+// historyAction prints the stories last fetched or opened
+func historyAction(c *cli.Context) error {
+	path, err := hnreader.HistoryFile()
+	if err != nil {
+		return handleError(err)
+	}
+	h, err := hnreader.OpenHistory(path)
+	if err != nil {
+		return handleError(err)
+	}
+	entries, err := h.Entries()
+	h.Close()
+	if err != nil {
+		return handleError(err)
+	}
+
+	var news []hnreader.Story
+	for _, entry := range entries {
+		if len(news) == c.Int("number") {
+			break
+		}
+		if c.Bool("opened") && entry.Opened.IsZero() {
+			continue
+		}
+		news = append(news, entry.Story)
+	}
+
+	// kept for the open command, which is fine to go without
+	if path, err := hnreader.LastListingFile(); err == nil {
+		hnreader.SaveStories(path, news)
+	}
+	return handleError(writeOutput(c, news))
+}
+
+// withHistory gives the history to record, which is fine to go without
+// when another hnreader holds it or it can't be opened
+func withHistory(record func(h *hnreader.History) error) {
+	updateHistory(record)
+}
+
+// updateHistory opens the history for record, failing when it can't be
+// opened
+func updateHistory(record func(h *hnreader.History) error) error {
+	path, err := hnreader.HistoryFile()
+	if err != nil {
+		return err
+	}
+	h, err := hnreader.OpenHistory(path)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	return record(h)
+}
+
+// recordOpened records the opened stories in the history, which marks them
+// as read for --unread-only
+func recordOpened(news []hnreader.Story) {
+	if len(news) == 0 {
+		return
+	}
+	withHistory(func(h *hnreader.History) error {
+		return h.RecordOpened(news, time.Now())
+	})
+}
+
+// readURLs returns the canonical URLs of the stories opened or marked as
+// read before
+func readURLs() (map[string]bool, error) {
+	var read map[string]bool
+	err := updateHistory(func(h *hnreader.History) error {
+		var err error
+		read, err = h.ReadURLs()
+		return err
+	})
+	return read, err
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getLaterCommands return the subcommands of the later command
+func getLaterCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Queue stories of the last listing by their numbers, or pages by their URL",
+			ArgsUsage: "<number|first-last|URL>...",
+			Flags:     getNetworkFlags(),
+			Action:    laterAddAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "Print the queue, the next story first",
+			Flags:  getOutputFlags(),
+			Action: laterListAction,
+		},
+		{
+			Name:   "open",
+			Usage:  "Open the next stories of the queue and take them off it",
+			Flags:  getLaterOpenFlags(),
+			Action: laterOpenAction,
+		},
+	}
+}
+
+// getLaterOpenFlags return the flags of the later open command
+func getLaterOpenFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "count",
+			Value:   1,
+			Aliases: []string{"n"},
+			Usage:   "Number of stories to open\t",
+		},
+		&cli.BoolFlag{
+			Name:  "lifo",
+			Usage: "Open the last queued stories first instead of the first ones\t",
+		},
+	}
+	return append(flags, getOpenFlags()...)
+}
+
+// laterAddAction queues stories of the last listing and pages
+func laterAddAction(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return handleError(errors.New("later add needs story numbers or URLs, e.g. hnreader later add 3 https://example.com"))
+	}
+	stories, err := argStories(c)
+	if err != nil {
+		return handleError(err)
+	}
+	err = updateHistory(func(h *hnreader.History) error {
+		return h.PushLater(stories...)
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	for _, story := range stories {
+		fmt.Printf("%s %s\n", blue("Queued"), storyTitle(story))
+	}
+	return nil
+}
+
+// laterListAction prints the queue
+func laterListAction(c *cli.Context) error {
+	var news []hnreader.Story
+	err := updateHistory(func(h *hnreader.History) error {
+		var err error
+		news, err = h.Later()
+		return err
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return handleError(writeOutput(c, news))
+}
+
+// laterOpenAction opens the next stories of the queue, which are taken off
+// it whether or not they open
+func laterOpenAction(c *cli.Context) error {
+	var news []hnreader.Story
+	err := updateHistory(func(h *hnreader.History) error {
+		var err error
+		news, err = h.PopLater(c.Int("count"), c.Bool("lifo"))
+		return err
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	if len(news) == 0 {
+		return handleError(errors.New("nothing to read later, queue stories with hnreader later add"))
+	}
+	return handleError(openTabs(c, pagesToOpen(news, c.Bool("comments"), c.Bool("both"))))
+}
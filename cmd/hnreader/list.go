@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getListFlags return the flags of the list command
+func getListFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "number",
+			Value:   10,
+			Aliases: []string{"n"},
+			Usage:   "Specify number of stories\t",
+		},
+		&cli.StringFlag{
+			Name:    "filter",
+			Aliases: []string{"f"},
+			Usage:   "Only print the stories whose title or domain fuzzily matches every word, like \"rust async\"\t",
+		},
+		&cli.BoolFlag{
+			Name:  "reading-time",
+			Usage: "Fetch each article to tell how long it takes to read\t",
+		},
+	}
+	flags = append(flags, getSourceFlags()...)
+	flags = append(flags, getFilterFlags()...)
+	flags = append(flags, getOutputFlags()...)
+	return append(flags, getNetworkFlags()...)
+}
+
+// listAction prints the stories of the chosen sources
+func listAction(c *cli.Context) error {
+	src, count, err := newSourceFetcher(c, "number")
+	if err != nil {
+		return handleError(err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	if streamsJSONL(c) {
+		news, err := streamStories(ctx, c, src, count)
+		reportFetchError(c, err)
+		saveListing(news)
+		return nil
+	}
+
+	news, err := fetchStories(ctx, c, src, count)
+	reportFetchError(c, err)
+	news = hnreader.FuzzyFilter(c.String("filter"), news)
+	if err := estimateReadingTimes(ctx, c, news); err != nil {
+		return handleError(err)
+	}
+	saveListing(news)
+
+	return handleError(writeOutput(c, news))
+}
+
+// saveListing keeps the listed stories for the open command, which is fine
+// to go without
+func saveListing(news []hnreader.Story) {
+	if path, err := hnreader.LastListingFile(); err == nil {
+		hnreader.SaveStories(path, news)
+	}
+}
+
+// streamsJSONL tells whether the stories are printed as JSON lines while
+// they are fetched, which the flags needing all of them at once rule out
+func streamsJSONL(c *cli.Context) bool {
+	return c.String("output") == outputJSONL && !c.IsSet("format") && !c.Bool("clipboard") && c.String("file") == "" &&
+		c.String("sort-by") == "" && !c.Bool("shuffle") && c.Int("sample") == 0 && !c.Bool("reading-time")
+}
+
+// streamStories prints up to count stories of src that pass the filter
+// flags as JSON lines, as each source's batch arrives, and returns them.
+// The stories are tagged and recorded like fetchStories does.
+func streamStories(ctx context.Context, c *cli.Context, src hnreader.Fetcher, count int) ([]hnreader.Story, error) {
+	config, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newFilter(c, config)
+	if err != nil {
+		return nil, err
+	}
+	fetch := count
+	if filter != nil {
+		fetch = count * overfetch
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	var listed []hnreader.Story
+	write := func(batch []hnreader.Story) {
+		hnreader.MarkPaywalled(batch, config.Paywalls)
+		if filter != nil {
+			batch = filter.Apply(batch)
+		}
+		for _, story := range hnreader.FuzzyFilter(c.String("filter"), batch) {
+			if len(listed) == count {
+				return
+			}
+			enc.Encode(story)
+			listed = append(listed, story)
+		}
+	}
+
+	// a single source has a single batch
+	multi, isMulti := src.(*hnreader.MultiSource)
+	if isMulti {
+		multi.Batch = write
+	}
+	news, err := src.Fetch(ctx, fetch)
+	if !isMulti {
+		write(news)
+	}
+	withHistory(func(h *hnreader.History) error {
+		return h.RecordFetched(news, time.Now())
+	})
+	return listed, err
+}
+
+// estimateReadingTimes fills in the reading time of the stories when
+// --reading-time is given
+func estimateReadingTimes(ctx context.Context, c *cli.Context, news []hnreader.Story) error {
+	if !c.Bool("reading-time") {
+		return nil
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	hnreader.EstimateReadingTimes(ctx, client, news, hnreader.DefaultWorkers)
+	return nil
+}
+
+// printStories writes a numbered listing of stories
+func printStories(w io.Writer, news []hnreader.Story) {
+	now := time.Now()
+	for i, story := range news {
+		line := yellow(fmt.Sprintf("%2d.", i+1)) + " " + storyTitle(story)
+		if domain := story.Domain(); domain != "" && story.Title != "" {
+			line += " (" + domain + ")"
+		}
+		if len(story.Tags) > 0 {
+			line += " " + yellow("["+strings.Join(story.Tags, ", ")+"]")
+		}
+		var details []string
+		if story.HasScore {
+			details = append(details, fmt.Sprintf("%d points", story.Score))
+		}
+		if !story.PublishedAt.IsZero() {
+			details = append(details, ago(story.PublishedAt, now))
+		}
+		if story.ReadingMinutes != 0 {
+			details = append(details, fmt.Sprintf("%d min read", story.ReadingMinutes))
+		}
+		if len(details) > 0 {
+			line += " " + blue("("+strings.Join(details, ", ")+")")
+		}
+		fmt.Fprintln(w, line)
+		fmt.Fprintf(w, "    %s\n", story.URL)
+	}
+}
+
+// logOpening tells which story is being opened, as its address often says
+// little about it
+func logOpening(w io.Writer, story hnreader.Story) {
+	fmt.Fprintf(w, "%s %s\n", blue("Opening"), storyTitle(story))
+}
+
+// ago tells how long before now t is, like "3h ago"
+func ago(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	}
+	// months are too coarse to be of any use
+	return t.Format("2 Jan 2006")
+}
+
+// storyTitle returns the title of the story, or its address for the sources
+// that don't give one
+func storyTitle(story hnreader.Story) string {
+	if story.Title == "" {
+		return story.URL
+	}
+	return story.Title
+}
@@ -0,0 +1,709 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	"github.com/fatih/color"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// Colors for console output
+var blue = color.New(color.FgBlue, color.Bold).SprintFunc()
+var yellow = color.New(color.FgYellow, color.Bold).SprintFunc()
+var red = color.New(color.FgRed, color.Bold).SprintFunc()
+
+type logWriter struct{}
+
+func (writer logWriter) Write(bytes []byte) (int, error) {
+	return fmt.Print(yellow("[") + time.Now().UTC().Format("15:04:05") + yellow("]") + string(bytes))
+}
+
+// checkGoPath checks for GOPATH
+func checkGoPath() error {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		log.Fatal(red("$GOPATH isn't set up properly..."))
+	}
+	return nil
+}
+
+// handleError go convention
+func handleError(err error) error {
+	if err != nil {
+		fmt.Println(red(err.Error()))
+	}
+	return nil
+}
+
+func init() {
+	log.SetFlags(0)
+	log.SetOutput(new(logWriter))
+}
+
+// quoteAll quotes each name and joins them with commas
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// splitList splits a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// randomSources returns the sources random and --all pick from, leaving
+// out those that can't run without extra options
+func randomSources() []string {
+	var names []string
+	for _, src := range hnreader.Sources() {
+		if !src.NeedsOptions {
+			names = append(names, src.Name)
+		}
+	}
+	return names
+}
+
+// getAllFlags return all flags for the command line
+func getAllFlags(includeSource bool) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "tabs",
+			Value:   10,
+			Aliases: []string{"t"},
+			Usage:   "Specify number of tabs\t",
+		},
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+		&cli.BoolFlag{
+			Name:  "clipboard",
+			Usage: "Copy the URLs to the clipboard instead of opening them\t",
+		},
+		&cli.BoolFlag{
+			Name:  "comments",
+			Usage: "Open the discussions of the stories instead of the articles\t",
+		},
+		&cli.BoolFlag{
+			Name:  "both",
+			Usage: "Open the discussion of each story next to its article\t",
+		},
+		&cli.UintFlag{
+			Name:  "batch",
+			Usage: "Open this many tabs at a time, pressing Enter for the next ones\t",
+		},
+		&cli.DurationFlag{
+			Name:  "batch-delay",
+			Usage: "Wait this long between batches instead of for Enter\t",
+		},
+		yesFlag(),
+		&cli.StringFlag{
+			Name:  "save-session",
+			Usage: "Save the opened tabs as a session of this name, reopened with hnreader session restore\t",
+		},
+	}
+
+	if includeSource {
+		flags = append(flags, getSourceFlags()...)
+	}
+	flags = append(flags, getFilterFlags()...)
+
+	return append(flags, getNetworkFlags()...)
+}
+
+// getSourceFlags return the flags choosing and configuring the source
+func getSourceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "source",
+			Value:   "hn",
+			Aliases: []string{"s"},
+			Usage:   fmt.Sprintf("Specify news source, or several separated by commas (one of %s)\t", quoteAll(hnreader.SourceNames())),
+		},
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "Open a few stories from every source that needs no options\t",
+		},
+		&cli.IntFlag{
+			Name:  "per-source",
+			Value: 2,
+			Usage: "Stories taken from each source with --all\t",
+		},
+		&cli.StringFlag{
+			Name:  "mix",
+			Usage: "Sources weighted by their share of the tabs, like hn=60%,reddit=30%,lobsters=10%\t",
+		},
+		&cli.StringFlag{
+			Name:  "mixing",
+			Value: hnreader.MixRoundRobin,
+			Usage: "How stories of several sources are mixed (one of round-robin, proportional)\t",
+		},
+		&cli.StringFlag{
+			Name:    "mode",
+			Aliases: []string{"m"},
+			Usage:   "Listing to fetch from sources that have several (hn: top, best, newest, ask, show, jobs; echojs: latest, top; lobsters: hottest, newest, recent; pinboard: popular, recent; reddit: saved; stackoverflow: hot, newest, active, votes)\t",
+		},
+		&cli.StringFlag{
+			Name:  "url",
+			Usage: "Address of the feed read by the rss source, or of the page whose feed the auto source finds\t",
+		},
+		&cli.StringFlag{
+			Name:  "path",
+			Usage: "File of URLs, one per line, read by the file source\t",
+		},
+		&cli.StringFlag{
+			Name:  "opml",
+			Usage: "Read every feed of this OPML file, implies --source opml\t",
+		},
+		&cli.StringFlag{
+			Name:  "query",
+			Usage: "Keywords searched by sources that support it (newsapi)\t",
+		},
+		&cli.StringFlag{
+			Name:  "tags",
+			Usage: "Comma separated tags for sources that support them (devto, hackernoon, pinboard, stackoverflow)\t",
+		},
+		&cli.StringFlag{
+			Name:  "topic",
+			Usage: "Section or topic for sources that have them (ars: gadgets, tech-policy, ...; gnews: technology, science, ...; infoq: architecture, ai-ml, java, ...)\t",
+		},
+		&cli.StringFlag{
+			Name:  "category",
+			Usage: "Comma separated categories for sources that have them (arxiv: cs.LG, cs.DC, ...; newsapi: technology, science, ...)\t",
+		},
+		&cli.StringFlag{
+			Name:  "hl",
+			Usage: "Language and region of the gnews edition or newsapi headlines (default: en-US)\t",
+		},
+		&cli.StringFlag{
+			Name:  "zone",
+			Usage: "DZone zone to read, like java, devops or cloud (default: home)\t",
+		},
+		&cli.StringFlag{
+			Name:  "group",
+			Usage: "Comma separated groups for the tildes source (default: comp,tech)\t",
+		},
+		&cli.StringFlag{
+			Name:  "lang",
+			Usage: "Programming language of the github-trending source, like go\t",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Time range of the github-trending source (one of daily, weekly, monthly)\t",
+		},
+		&cli.StringFlag{
+			Name:  "instance",
+			Usage: "Server of the mastodon source (default: mastodon.social)\t",
+		},
+		&cli.StringFlag{
+			Name:  "ecosystem",
+			Usage: "Comma separated package ecosystems of the advisories source, like go or npm\t",
+		},
+		&cli.StringFlag{
+			Name:  "severity",
+			Usage: "Lowest severity of the advisories source (one of low, medium, high, critical)\t",
+		},
+		&cli.StringFlag{
+			Name:  "subreddit",
+			Usage: "Comma separated subreddits for the reddit source (default: programming)\t",
+		},
+		&cli.StringFlag{
+			Name:  "multi",
+			Usage: "Multireddit for the reddit source, like user/m/name\t",
+		},
+		&cli.StringFlag{
+			Name:  "sort",
+			Usage: "Order of the reddit listing (one of hot, new, top, rising)\t",
+		},
+		&cli.StringFlag{
+			Name:  "time",
+			Usage: "Time window of reddit's top listing (one of hour, day, week, month, year, all)\t",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-nsfw",
+			Value: true,
+			Usage: "Skip the reddit posts marked NSFW, --skip-nsfw=false keeps them\t",
+		},
+		&cli.BoolFlag{
+			Name:  "links-only",
+			Usage: "Skip reddit's text posts, only keeping those linking to articles\t",
+		},
+	}
+}
+
+// getNetworkFlags return the flags tuning how sources are fetched
+func getNetworkFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "proxy",
+			Usage: "Send requests through this proxy URL instead of $HTTPS_PROXY\t",
+		},
+		&cli.BoolFlag{
+			Name:  "no-cache",
+			Usage: "Don't read or write the response cache\t",
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Value: hnreader.DefaultRetries,
+			Usage: "Retry failed requests this many times\t",
+		},
+		&cli.DurationFlag{
+			Name:  "retry-backoff",
+			Value: hnreader.DefaultRetryBackoff,
+			Usage: "Wait this long before the first retry, doubling after each attempt\t",
+		},
+		&cli.Float64Flag{
+			Name:  "rate",
+			Value: hnreader.DefaultRate,
+			Usage: "Maximum requests per second sent to a single site, 0 for no limit\t",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Value: 30 * time.Second,
+			Usage: "Give up on slow sources after this long and open what was fetched\t",
+		},
+	}
+}
+
+// newClient builds the HTTP client described by the network flags
+func newClient(c *cli.Context) (*http.Client, error) {
+	opts := hnreader.ClientOptions{
+		Proxy:        c.String("proxy"),
+		Retries:      c.Int("retries"),
+		RetryBackoff: c.Duration("retry-backoff"),
+		RetryJitter:  hnreader.DefaultRetryJitter,
+		Rate:         c.Float64("rate"),
+	}
+	if !c.Bool("no-cache") {
+		// running without a cache is fine when there is nowhere to put it
+		opts.CacheDir, _ = hnreader.CacheDir()
+	}
+
+	return hnreader.NewClient(opts)
+}
+
+// loadConfig reads the file given by --config, or the default one
+func loadConfig(c *cli.Context) (*hnreader.Config, error) {
+	path := c.String("config")
+	if path == "" {
+		var err error
+		if path, err = hnreader.DefaultConfigPath(); err != nil {
+			// no home directory, so no configuration either
+			return new(hnreader.Config), nil
+		}
+	}
+	return hnreader.LoadConfig(path)
+}
+
+// newContext returns a context that is cancelled after --timeout, or never
+// when it is 0
+func newContext(c *cli.Context) (context.Context, context.CancelFunc) {
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// newFetcher creates the fetcher of one source, or fills multi with the
+// fetchers of several
+func newFetcher(names []string, multi *hnreader.MultiSource, opts hnreader.Options) (hnreader.Fetcher, error) {
+	switch len(names) {
+	case 0:
+		return nil, errors.New("no source given")
+	case 1:
+		return hnreader.NewFetcher(names[0], opts)
+	}
+
+	for _, name := range names {
+		src, err := hnreader.NewFetcher(name, opts)
+		if err != nil {
+			return nil, err
+		}
+		multi.Fetchers = append(multi.Fetchers, src)
+	}
+	return multi, nil
+}
+
+// parseMix reads the sources and weights of --mix. Weights may be written as
+// percentages or plain numbers, only their ratios matter.
+func parseMix(value string) ([]string, []float64, error) {
+	var names []string
+	var weights []float64
+	for _, item := range splitList(value) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("--mix %q: expected source=weight", item)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(parts[1]), "%"), 64)
+		if err != nil || weight < 0 {
+			return nil, nil, fmt.Errorf("--mix %q: invalid weight", item)
+		}
+		names = append(names, strings.TrimSpace(parts[0]))
+		weights = append(weights, weight)
+	}
+	return names, weights, nil
+}
+
+// newSourceFetcher creates the fetcher described by the source flags and
+// returns it with the number of stories to get, read from countFlag
+func newSourceFetcher(c *cli.Context, countFlag string) (hnreader.Fetcher, int, error) {
+	rand.Seed(time.Now().Unix())
+
+	config, err := prepareSources(c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, perSource := c.Int(countFlag), 0
+	var srcNames []string
+	var weights []float64
+	switch {
+	case c.IsSet("mix"):
+		if srcNames, weights, err = parseMix(c.String("mix")); err != nil {
+			return nil, 0, err
+		}
+	case c.Command.Name == "random":
+		names := randomSources()
+		srcNames = []string{names[rand.Intn(len(names))]}
+	case c.Bool("all"):
+		srcNames = randomSources()
+		perSource = c.Int("per-source")
+		// the whole batch is used unless told otherwise
+		if !c.IsSet(countFlag) {
+			count = perSource * len(srcNames)
+		}
+	case c.IsSet("opml") && !c.IsSet("source"):
+		srcNames = []string{"opml"}
+	default:
+		srcNames = splitList(c.String("source"))
+	}
+
+	client, err := newClient(c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var redditAuth *hnreader.RedditAuth
+	if config.Reddit.Configured() {
+		tokenFile, _ := hnreader.RedditTokenFile()
+		redditAuth = &hnreader.RedditAuth{Credentials: config.Reddit, TokenFile: tokenFile, Client: client}
+	}
+
+	mixing := c.String("mixing")
+	if weights != nil && !c.IsSet("mixing") {
+		mixing = hnreader.MixProportional
+	}
+
+	src, err := newFetcher(srcNames, &hnreader.MultiSource{Mix: mixing, PerSource: perSource, Weights: weights}, hnreader.Options{
+		Client:     client,
+		Mode:       c.String("mode"),
+		Subreddits: splitList(c.String("subreddit")),
+		Sort:       c.String("sort"),
+		Time:       c.String("time"),
+		Multi:      c.String("multi"),
+		// random has no source flags, and NSFW posts are skipped unless
+		// told otherwise
+		NSFW:       c.IsSet("skip-nsfw") && !c.Bool("skip-nsfw"),
+		LinksOnly:  c.Bool("links-only"),
+		URL:        c.String("url"),
+		OPML:       c.String("opml"),
+		Path:       c.String("path"),
+		Tags:       splitList(c.String("tags")),
+		Topic:      c.String("topic"),
+		Zone:       c.String("zone"),
+		Language:   c.String("lang"),
+		Since:      c.String("since"),
+		Repos:      config.GitHub.Repos,
+		Groups:     splitList(c.String("group")),
+		Instance:   c.String("instance"),
+		Categories: splitList(c.String("category")),
+		Ecosystems: splitList(c.String("ecosystem")),
+		Severity:   c.String("severity"),
+		Locale:     c.String("hl"),
+		Query:      c.String("query"),
+		NewsAPIKey: config.NewsAPI.Key,
+		RedditAuth: redditAuth,
+	})
+	return src, count, err
+}
+
+// getAllActions return all action for the command line
+func getAllActions(c *cli.Context) error {
+	src, tabs, err := newSourceFetcher(c, "tabs")
+	if err != nil {
+		return handleError(err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	news, err := fetchStories(ctx, c, src, tabs)
+	handleError(err)
+	if len(news) > tabs {
+		news = news[:tabs]
+	}
+	news = pagesToOpen(news, c.Bool("comments"), c.Bool("both"))
+
+	if c.Bool("clipboard") {
+		var buf bytes.Buffer
+		writeURLs(&buf, news)
+		if err := saveSession(c, news); err != nil {
+			return handleError(err)
+		}
+		return handleError(hnreader.CopyToClipboard(buf.String()))
+	}
+
+	return handleError(openTabs(c, news))
+}
+
+// yesFlag returns the flag skipping the confirmation of openTabs
+func yesFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "yes",
+		Aliases: []string{"y"},
+		Usage:   "Open the tabs without asking, however many there are\t",
+	}
+}
+
+// openTabs opens the pages of the stories in the browser of --browser,
+// asking first when there are more than the configuration allows unless
+// --yes is given. They are saved as a session with --save-session and
+// opened in batches with --batch.
+func openTabs(c *cli.Context, news []hnreader.Story) error {
+	if !c.Bool("yes") {
+		config, err := loadConfig(c)
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmTabs(os.Stdin, os.Stdout, news, config.ConfirmThreshold()); !ok {
+			return err
+		}
+	}
+	if err := saveSession(c, news); err != nil {
+		return err
+	}
+
+	if batch := c.Int("batch"); batch > 0 {
+		return runBatches(news, batch, c.String("browser"), c.Duration("batch-delay"))
+	}
+	return openAll(news, c.String("browser"))
+}
+
+// openAll opens the stories one after the other, telling which, and
+// records the opened ones in the history
+func openAll(news []hnreader.Story, browser string) error {
+	var opened []hnreader.Story
+	defer func() { recordOpened(opened) }()
+	for _, story := range news {
+		if err := openStory(story, browser); err != nil {
+			return err
+		}
+		opened = append(opened, story)
+	}
+	return nil
+}
+
+// openStory tells which story is opened and opens it
+func openStory(story hnreader.Story, browser string) error {
+	logOpening(os.Stdout, story)
+	return hnreader.Open(story.URL, browser)
+}
+
+// pagesToOpen returns the stories with the address of their discussion
+// instead of the article with comments, or next to it with both. Stories
+// without a discussion keep their article.
+func pagesToOpen(news []hnreader.Story, comments, both bool) []hnreader.Story {
+	if !comments && !both {
+		return news
+	}
+	var pages []hnreader.Story
+	for _, story := range news {
+		if story.CommentsURL == "" || story.CommentsURL == story.URL {
+			pages = append(pages, story)
+			continue
+		}
+		if both {
+			pages = append(pages, story)
+		}
+		discussion := story
+		discussion.URL = story.CommentsURL
+		pages = append(pages, discussion)
+	}
+	return pages
+}
+
+// confirmTabs lists the stories on out and asks on in whether to open them
+// when there are more than threshold, and tells whether to go ahead. It
+// fails when in has nothing left to answer with, like when the stories
+// were read from stdin.
+func confirmTabs(in io.Reader, out io.Writer, news []hnreader.Story, threshold int) (bool, error) {
+	if threshold < 0 || len(news) <= threshold {
+		return true, nil
+	}
+
+	printStories(out, news)
+	fmt.Fprintf(out, "About to open these %d tabs, continue? [y/N] ", len(news))
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err == io.EOF && answer == "" {
+		fmt.Fprintln(out)
+		return false, fmt.Errorf("no answer to open %d tabs, pass --yes to open them", len(news))
+	}
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func main() {
+	app := hnreader.Init()
+
+	cli := &cli.App{
+		Name:    app.Name,
+		Version: app.Version,
+		Authors: []*cli.Author{
+			{
+				Name:  app.Author,
+				Email: app.Email,
+			},
+		},
+		Usage: app.Description,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "Read the configuration from this file instead of $XDG_CONFIG_HOME/hnreader/config.json",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:    "run",
+				Aliases: []string{"r"},
+				Usage:   "Start hnreader with default option (10 news and chrome browser)",
+				Flags:   getAllFlags(true),
+				Action:  getAllActions,
+				Before: func(c *cli.Context) error {
+					app.Information()
+					checkGoPath()
+					return nil
+				},
+			},
+			{
+				Name:    "random",
+				Aliases: []string{"rr"},
+				Usage:   "Start hnreader with a randomized source of news",
+				Flags:   getAllFlags(false),
+				Action:  getAllActions,
+				Before: func(c *cli.Context) error {
+					app.Information()
+					checkGoPath()
+					return nil
+				},
+			},
+			{
+				Name:      "search",
+				Aliases:   []string{"s"},
+				Usage:     "Search Hacker News stories and list or open them",
+				ArgsUsage: "<query>",
+				Flags:     getSearchFlags(),
+				Action:    searchAction,
+			},
+			{
+				Name:    "list",
+				Aliases: []string{"l"},
+				Usage:   "Print stories instead of opening them",
+				Flags:   getListFlags(),
+				Action:  listAction,
+			},
+			{
+				Name:      "open",
+				Aliases:   []string{"o"},
+				Usage:     "Open stories of the last listing by their numbers",
+				ArgsUsage: "<number|first-last>...",
+				Flags:     getOpenFlags(),
+				Action:    openAction,
+			},
+			{
+				Name:      "comments",
+				Aliases:   []string{"c"},
+				Usage:     "Read the comments of a Hacker News story",
+				ArgsUsage: "<story ID|URL>",
+				Flags:     getCommentsFlags(),
+				Action:    commentsAction,
+			},
+			{
+				Name:   "tui",
+				Usage:  "Pick the stories to open from an interactive list",
+				Flags:  getTUIFlags(),
+				Action: tuiAction,
+			},
+			{
+				Name:        "bookmark",
+				Aliases:     []string{"bm"},
+				Usage:       "Save stories locally and reopen them later",
+				Subcommands: getBookmarkCommands(),
+			},
+			{
+				Name:        "later",
+				Usage:       "Queue stories to read later and open the next ones",
+				Subcommands: getLaterCommands(),
+			},
+			{
+				Name:        "session",
+				Usage:       "Reopen the tabs of a run saved with --save-session",
+				Subcommands: getSessionCommands(),
+			},
+			{
+				Name:   "history",
+				Usage:  "Print the stories fetched or opened before, the last seen first",
+				Flags:  getHistoryFlags(),
+				Action: historyAction,
+			},
+			{
+				Name:   "diff",
+				Usage:  "Print or open only the stories that appeared since the last diff of the same listing",
+				Flags:  getDiffFlags(),
+				Action: diffAction,
+			},
+			{
+				Name:   "digest",
+				Usage:  "Write the stories to a single HTML page, grouped by source",
+				Flags:  getDigestFlags(),
+				Action: digestAction,
+			},
+			{
+				Name:   "sources",
+				Usage:  "List the available sources with their flags",
+				Action: sourcesAction,
+			},
+		},
+	}
+
+	cli.Run(os.Args)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"golang", "rust", "devops"}, splitList("golang, rust,,devops "))
+	assert.Nil(t, splitList(""))
+}
+
+func TestQuoteAll(t *testing.T) {
+	assert.Equal(t, `"hn", "reddit"`, quoteAll([]string{"hn", "reddit"}))
+}
+
+func TestRandomSources(t *testing.T) {
+	names := randomSources()
+	assert.Contains(t, names, "hn")
+	assert.NotContains(t, names, "rss")
+	assert.NotContains(t, names, "stdin")
+}
+
+func TestParseMix(t *testing.T) {
+	names, weights, err := parseMix("hn=60%, reddit=30%,lobsters=10")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"hn", "reddit", "lobsters"}, names)
+	assert.Equal(t, []float64{60, 30, 10}, weights)
+
+	_, _, err = parseMix("hn")
+	assert.NotNil(t, err)
+	_, _, err = parseMix("hn=lots")
+	assert.NotNil(t, err)
+}
+
+func TestSourceFlagsAreKnown(t *testing.T) {
+	known := make(map[string]bool)
+	for _, flag := range getSourceFlags() {
+		for _, name := range flag.Names() {
+			known[name] = true
+		}
+	}
+
+	for _, src := range hnreader.Sources() {
+		for _, flag := range src.Flags {
+			assert.True(t, known[flag], "%s: unknown flag --%s", src.Name, flag)
+		}
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	news := []hnreader.Story{
+		{Title: "First", URL: "https://example.com/1", Score: 42},
+		{Title: "Second", URL: "https://example.com/2"},
+	}
+	assert.Nil(t, writeJSONL(&buf, news))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	var story hnreader.Story
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &story))
+	assert.Equal(t, news[0].Title, story.Title)
+	assert.Equal(t, news[0].Score, story.Score)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	news := []hnreader.Story{
+		{Title: "Hello, world", URL: "https://example.com/1", Score: 42, Source: "hn", Author: "pg", PublishedAt: time.Date(2018, 10, 2, 15, 4, 5, 0, time.UTC)},
+	}
+	assert.Nil(t, writeCSV(&buf, news, true))
+	assert.Equal(t, "title,url,comments_url,score,comments,source,published_at,author,tags\n"+
+		"\"Hello, world\",https://example.com/1,,42,0,hn,2018-10-02T15:04:05Z,pg,\n", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, writeCSV(&buf, news[:0], false))
+	assert.Empty(t, buf.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	news := []hnreader.Story{
+		{Title: "Show HN: [beta] tool", URL: "https://example.com/1", Score: 42, HasScore: true, Source: "hn"},
+		{Title: "Plain", URL: "https://example.com/2"},
+		{Title: "Flagged", URL: "https://example.com/3", HasScore: true},
+	}
+	assert.Nil(t, writeMarkdown(&buf, news))
+	assert.Equal(t, "- [Show HN: \\[beta\\] tool](https://example.com/1) — hn, 42 points\n"+
+		"- [Plain](https://example.com/2)\n"+
+		"- [Flagged](https://example.com/3) — 0 points\n", buf.String())
+}
+
+func TestWriteTemplate(t *testing.T) {
+	tmpl, err := parseFormat(`{{.Title}}\t{{.URL}}`)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	news := []hnreader.Story{{Title: "First", URL: "https://example.com/1"}}
+	assert.Nil(t, writeTemplate(&buf, tmpl, news))
+	assert.Equal(t, "First\thttps://example.com/1\n", buf.String())
+
+	_, err = parseFormat("{{.Title")
+	assert.NotNil(t, err)
+	tmpl, _ = parseFormat("{{.Missing}}")
+	assert.NotNil(t, writeTemplate(&buf, tmpl, news))
+}
+
+func TestWriteURLs(t *testing.T) {
+	var buf bytes.Buffer
+	news := []hnreader.Story{{Title: "First", URL: "https://example.com/1"}, {Title: "Second", URL: "https://example.com/2"}}
+	assert.Nil(t, writeURLs(&buf, news))
+	assert.Equal(t, "https://example.com/1\nhttps://example.com/2\n", buf.String())
+}
+
+func TestParseIndexes(t *testing.T) {
+	indexes, err := parseIndexes([]string{"2", "5", "7-9"}, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 4, 6, 7, 8}, indexes)
+
+	indexes, err = parseIndexes([]string{"1,3"}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 2}, indexes)
+
+	for _, args := range [][]string{{"0"}, {"11"}, {"two"}, {"9-7"}, {"8-12"}} {
+		_, err = parseIndexes(args, 10)
+		assert.NotNil(t, err, "%v", args)
+	}
+}
+
+func TestPickStories(t *testing.T) {
+	news := []hnreader.Story{{Title: "One"}, {Title: "Two"}, {Title: "Three"}}
+	picked, err := pickStories(news, []string{"3", "1-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, []hnreader.Story{news[2], news[0], news[1]}, picked)
+
+	_, err = pickStories(news, []string{"4"})
+	assert.NotNil(t, err)
+
+	assert.True(t, isURL("https://example.com/post"))
+	assert.False(t, isURL("2-3"))
+}
+
+func TestConfirmTabs(t *testing.T) {
+	news := make([]hnreader.Story, 42)
+	for i := range news {
+		news[i] = hnreader.Story{Title: fmt.Sprintf("Story %d", i+1), URL: fmt.Sprintf("https://example.com/%d", i+1)}
+	}
+	var out bytes.Buffer
+
+	ok, err := confirmTabs(strings.NewReader(""), &out, news[:15], 15)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	ok, _ = confirmTabs(strings.NewReader(""), &out, news, -1)
+	assert.True(t, ok)
+	assert.Empty(t, out.String())
+
+	ok, _ = confirmTabs(strings.NewReader("y\n"), &out, news, 15)
+	assert.True(t, ok)
+	// the titles are listed before asking
+	assert.Contains(t, out.String(), "Story 42")
+	assert.Contains(t, out.String(), "About to open these 42 tabs")
+	ok, _ = confirmTabs(strings.NewReader("YES\n"), &out, news, 15)
+	assert.True(t, ok)
+	ok, _ = confirmTabs(strings.NewReader("\n"), &out, news, 15)
+	assert.False(t, ok)
+	ok, err = confirmTabs(strings.NewReader("n"), &out, news, 15)
+	assert.False(t, ok)
+	assert.Nil(t, err)
+	// stdin was read to the end already
+	ok, err = confirmTabs(strings.NewReader(""), &out, news, 15)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "no answer to open 42 tabs, pass --yes to open them")
+}
+
+func TestPagesToOpen(t *testing.T) {
+	news := []hnreader.Story{
+		{Title: "Article", URL: "https://example.com/a", CommentsURL: "https://news.ycombinator.com/item?id=1"},
+		{Title: "Ask HN", URL: "https://news.ycombinator.com/item?id=2", CommentsURL: "https://news.ycombinator.com/item?id=2"},
+		{Title: "Feed item", URL: "https://example.com/b"},
+	}
+	urls := func(pages []hnreader.Story) []string {
+		var urls []string
+		for _, page := range pages {
+			urls = append(urls, page.URL)
+		}
+		return urls
+	}
+
+	assert.Equal(t, news, pagesToOpen(news, false, false))
+	assert.Equal(t, []string{"https://news.ycombinator.com/item?id=1", "https://news.ycombinator.com/item?id=2", "https://example.com/b"},
+		urls(pagesToOpen(news, true, false)))
+	assert.Equal(t, []string{"https://example.com/a", "https://news.ycombinator.com/item?id=1", "https://news.ycombinator.com/item?id=2", "https://example.com/b"},
+		urls(pagesToOpen(news, false, true)))
+}
+
+func TestAgo(t *testing.T) {
+	now := time.Date(2018, 10, 2, 15, 4, 5, 0, time.UTC)
+	tests := map[time.Duration]string{
+		-time.Minute:                 "just now",
+		30 * time.Second:             "just now",
+		5 * time.Minute:              "5m ago",
+		3*time.Hour + 59*time.Minute: "3h ago",
+		49 * time.Hour:               "2d ago",
+		60 * 24 * time.Hour:          "3 Aug 2018",
+	}
+	for age, expected := range tests {
+		assert.Equal(t, expected, ago(now.Add(-age), now), "%s", age)
+	}
+}
+
+func TestLogOpening(t *testing.T) {
+	var out bytes.Buffer
+	logOpening(&out, hnreader.Story{Title: "A story", URL: "https://example.com/a"})
+	logOpening(&out, hnreader.Story{URL: "https://example.com/b"})
+	assert.Contains(t, out.String(), "A story\n")
+	assert.Contains(t, out.String(), "https://example.com/b\n")
+	assert.NotContains(t, out.String(), "https://example.com/a")
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getOpenFlags return the flags of the open command
+func getOpenFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+		&cli.BoolFlag{
+			Name:  "comments",
+			Usage: "Open the discussions of the stories instead of the articles\t",
+		},
+		&cli.BoolFlag{
+			Name:  "both",
+			Usage: "Open the discussion of each story next to its article\t",
+		},
+		yesFlag(),
+	}
+}
+
+// parseIndexes reads story numbers and ranges like 7-9, counted from 1 as
+// in the listing, and returns them as indexes of a list of count stories
+func parseIndexes(args []string, count int) ([]int, error) {
+	var indexes []int
+	for _, arg := range args {
+		for _, item := range splitList(arg) {
+			bounds := strings.SplitN(item, "-", 2)
+			first, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a story number", item)
+			}
+			last := first
+			if len(bounds) == 2 {
+				if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+					return nil, fmt.Errorf("%q is not a range of story numbers", item)
+				}
+			}
+			if first < 1 || last > count {
+				return nil, fmt.Errorf("%s is out of the %d listed stories", item, count)
+			}
+			for n := first; n <= last; n++ {
+				indexes = append(indexes, n-1)
+			}
+		}
+	}
+	return indexes, nil
+}
+
+// openAction opens stories of the last listing by their numbers
+func openAction(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return handleError(errors.New("open needs the numbers of the stories, e.g. hnreader open 2 5 7-9"))
+	}
+
+	path, err := hnreader.LastListingFile()
+	if err != nil {
+		return handleError(err)
+	}
+	news, err := hnreader.LoadStories(path)
+	if err != nil {
+		return handleError(err)
+	}
+	if len(news) == 0 {
+		return handleError(errors.New("no stories listed yet, run hnreader list first"))
+	}
+
+	picked, err := pickStories(news, c.Args().Slice())
+	if err != nil {
+		return handleError(err)
+	}
+	return handleError(openTabs(c, pagesToOpen(picked, c.Bool("comments"), c.Bool("both"))))
+}
+
+// pickStories returns the stories given by their numbers and ranges, as
+// read by parseIndexes
+func pickStories(news []hnreader.Story, args []string) ([]hnreader.Story, error) {
+	indexes, err := parseIndexes(args, len(news))
+	if err != nil {
+		return nil, err
+	}
+	var picked []hnreader.Story
+	for _, i := range indexes {
+		picked = append(picked, news[i])
+	}
+	return picked, nil
+}
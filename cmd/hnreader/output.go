@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	"github.com/fatih/color"
+	"golang.org/x/term"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// Formats of the --output flag
+const (
+	outputText  = "text"
+	outputJSONL = "jsonl"
+	outputCSV   = "csv"
+	outputMD    = "md"
+	outputRSS   = "rss"
+	outputURLs  = "urls"
+)
+
+// feedLink is the channel link of the feed written by --output rss
+const feedLink = "https://github.com/Bunchhieng/hnreader"
+
+// outputFormats lists the formats of the --output flag
+var outputFormats = []string{outputText, outputJSONL, outputCSV, outputMD, outputRSS, outputURLs}
+
+// mdEscaper escapes the characters that would end the link text of a
+// Markdown link
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// templateEscapes turns the \t and \n typed in a --format template into the
+// tab and newline a shell's single quotes keep as is
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// csvHeader names the columns written by writeCSV
+var csvHeader = []string{"title", "url", "comments_url", "score", "comments", "source", "published_at", "author", "tags"}
+
+// getOutputFlags return the flags choosing how listed stories are printed
+func getOutputFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "output",
+			Value: outputText,
+			Usage: fmt.Sprintf("Format of the printed stories (one of %s)\t", strings.Join(outputFormats, ", ")),
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Print each story with this Go template instead, like '{{.Title}}\\t{{.URL}}'\t",
+		},
+		&cli.StringFlag{
+			Name:  "file",
+			Usage: "Append the stories to this file instead of printing them, or replace it with rss\t",
+		},
+		&cli.BoolFlag{
+			Name:  "clipboard",
+			Usage: "Copy the stories to the clipboard instead of printing them, as URLs unless --output is given\t",
+		},
+		&cli.BoolFlag{
+			Name:  "no-pager",
+			Usage: "Don't page the text listing through $PAGER when it doesn't fit in the terminal\t",
+		},
+	}
+}
+
+// writeOutput prints the stories with the --format template or in the format
+// given by --output, appends them to --file or copies them to the clipboard
+func writeOutput(c *cli.Context, news []hnreader.Story) error {
+	var tmpl *template.Template
+	if c.IsSet("format") {
+		var err error
+		if tmpl, err = parseFormat(c.String("format")); err != nil {
+			return err
+		}
+	}
+
+	format := c.String("output")
+	if c.Bool("clipboard") && !c.IsSet("output") {
+		format = outputURLs
+	}
+	if !isOutputFormat(format) {
+		return fmt.Errorf("unknown output %q (one of %s)", format, strings.Join(outputFormats, ", "))
+	}
+
+	w, empty := io.Writer(os.Stdout), true
+	if c.Bool("clipboard") {
+		if c.IsSet("file") {
+			return errors.New("--clipboard and --file can't be used together")
+		}
+		var buf bytes.Buffer
+		color.NoColor = true
+		if err := writeStories(&buf, format, tmpl, news, empty); err != nil {
+			return err
+		}
+		return hnreader.CopyToClipboard(buf.String())
+	}
+	if path := c.String("file"); path != "" {
+		mode := os.O_APPEND
+		if format == outputRSS && tmpl == nil {
+			// a feed is a single document, it can't be added to
+			mode = os.O_TRUNC
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|mode, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		// the CSV header is only needed once when appending to earlier runs
+		w, empty = f, info.Size() == 0
+		// colors would end up as escape codes in the file
+		color.NoColor = true
+	} else if tmpl == nil && format == outputText && !c.Bool("no-pager") {
+		// other formats are read by programs, which a pager would block
+		var buf bytes.Buffer
+		if err := writeStories(&buf, format, tmpl, news, empty); err != nil {
+			return err
+		}
+		return page(buf.Bytes())
+	}
+
+	return writeStories(w, format, tmpl, news, empty)
+}
+
+// page prints out on the standard output, through $PAGER (less -R by
+// default) when it is a terminal too short for it
+func page(out []byte) error {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	_, height, err := term.GetSize(fd)
+	if err != nil || bytes.Count(out, []byte("\n")) < height {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-R"}
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = bytes.NewReader(out)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return err
+		}
+		// no pager to be found, so print it all
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	return nil
+}
+
+// writeStories writes the stories with tmpl when it is set, in the given
+// format otherwise. The CSV header is only written to an empty w.
+func writeStories(w io.Writer, format string, tmpl *template.Template, news []hnreader.Story, empty bool) error {
+	switch {
+	case tmpl != nil:
+		return writeTemplate(w, tmpl, news)
+	case format == outputJSONL:
+		return writeJSONL(w, news)
+	case format == outputCSV:
+		return writeCSV(w, news, empty)
+	case format == outputMD:
+		return writeMarkdown(w, news)
+	case format == outputRSS:
+		return hnreader.WriteRSS(w, hnreader.AppName, feedLink, news)
+	case format == outputURLs:
+		return writeURLs(w, news)
+	default:
+		printStories(w, news)
+		return nil
+	}
+}
+
+// reportFetchError reports an error of the fetch. It goes to the standard
+// error when the stories are printed for another program, so it doesn't
+// end up among them.
+func reportFetchError(c *cli.Context, err error) {
+	if err == nil {
+		return
+	}
+	if c.String("output") == outputText && !c.IsSet("format") {
+		handleError(err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
+
+// isOutputFormat tells whether format is one of outputFormats
+func isOutputFormat(format string) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONL writes one JSON object per story and line, so each story can
+// be read as soon as it is written
+func writeJSONL(w io.Writer, news []hnreader.Story) error {
+	enc := json.NewEncoder(w)
+	for _, story := range news {
+		if err := enc.Encode(story); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeURLs writes the bare URL of each story, one per line
+func writeURLs(w io.Writer, news []hnreader.Story) error {
+	for _, story := range news {
+		if _, err := fmt.Fprintln(w, story.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the stories as CSV records, preceded by the column names
+// when header is set
+func writeCSV(w io.Writer, news []hnreader.Story, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		cw.Write(csvHeader)
+	}
+	for _, story := range news {
+		published := ""
+		if !story.PublishedAt.IsZero() {
+			published = story.PublishedAt.Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			story.Title,
+			story.URL,
+			story.CommentsURL,
+			strconv.Itoa(story.Score),
+			strconv.Itoa(story.Comments),
+			story.Source,
+			published,
+			story.Author,
+			strings.Join(story.Tags, ","),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeMarkdown writes the stories as a Markdown bullet list of links
+// followed by their source and points
+func writeMarkdown(w io.Writer, news []hnreader.Story) error {
+	for _, story := range news {
+		var details []string
+		if story.Source != "" {
+			details = append(details, story.Source)
+		}
+		if story.HasScore {
+			details = append(details, fmt.Sprintf("%d points", story.Score))
+		}
+
+		line := fmt.Sprintf("- [%s](%s)", mdEscaper.Replace(story.Title), story.URL)
+		if len(details) > 0 {
+			line += " — " + strings.Join(details, ", ")
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFormat parses the --format template, after replacing the \t and \n
+// escapes
+func parseFormat(format string) (*template.Template, error) {
+	tmpl, err := template.New("format").Parse(templateEscapes.Replace(format))
+	if err != nil {
+		return nil, fmt.Errorf("--format: %v", err)
+	}
+	return tmpl, nil
+}
+
+// writeTemplate executes tmpl for each story, ending every one with a newline
+func writeTemplate(w io.Writer, tmpl *template.Template, news []hnreader.Story) error {
+	for _, story := range news {
+		if err := tmpl.Execute(w, story); err != nil {
+			return fmt.Errorf("--format: %v", err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// dateLayout is the format of the --after and --before flags
+const dateLayout = "2006-01-02"
+
+// getSearchFlags return the flags of the search command
+func getSearchFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "tabs",
+			Value:   10,
+			Aliases: []string{"t"},
+			Usage:   "Specify number of results\t",
+		},
+		&cli.StringFlag{
+			Name:  "after",
+			Usage: "Only stories submitted on or after this date (YYYY-MM-DD)\t",
+		},
+		&cli.StringFlag{
+			Name:  "before",
+			Usage: "Only stories submitted before this date (YYYY-MM-DD)\t",
+		},
+		&cli.IntFlag{
+			Name:  "points",
+			Usage: "Only stories with at least this many points\t",
+		},
+		&cli.BoolFlag{
+			Name:  "by-date",
+			Usage: "Show the newest matches first instead of the most relevant\t",
+		},
+		&cli.BoolFlag{
+			Name:    "open",
+			Aliases: []string{"o"},
+			Usage:   "Open the results in the browser instead of listing them\t",
+		},
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+		yesFlag(),
+	}
+	flags = append(flags, getOutputFlags()...)
+
+	return append(flags, getNetworkFlags()...)
+}
+
+// parseDate parses an optional YYYY-MM-DD flag value
+func parseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return t, fmt.Errorf("--%s must be a date like 2018-10-02", name)
+	}
+	return t, nil
+}
+
+// searchAction runs a Hacker News search
+func searchAction(c *cli.Context) error {
+	query := strings.Join(c.Args().Slice(), " ")
+	if query == "" {
+		return handleError(errors.New("search needs a query, e.g. hnreader search rust async"))
+	}
+
+	after, err := parseDate("after", c.String("after"))
+	if err != nil {
+		return handleError(err)
+	}
+	before, err := parseDate("before", c.String("before"))
+	if err != nil {
+		return handleError(err)
+	}
+
+	client, err := newClient(c)
+	if err != nil {
+		return handleError(err)
+	}
+
+	src := &hnreader.HackerNewsSearchSource{
+		Client: client,
+		Query: hnreader.SearchQuery{
+			Query:     query,
+			After:     after,
+			Before:    before,
+			MinPoints: c.Int("points"),
+			ByDate:    c.Bool("by-date"),
+		},
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	news, err := src.Fetch(ctx, c.Int("tabs"))
+	reportFetchError(c, err)
+	if c.Bool("open") {
+		return handleError(openTabs(c, news))
+	}
+	return handleError(writeOutput(c, news))
+}
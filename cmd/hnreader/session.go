@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// getSessionCommands return the subcommands of the session command
+func getSessionCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "restore",
+			Usage:     "Reopen the tabs of a session saved with --save-session, or of a session file copied from another machine",
+			ArgsUsage: "<name|file.json>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "browser",
+					Value:   "",
+					Aliases: []string{"b"},
+					Usage:   "Specify browser\t",
+				},
+				yesFlag(),
+			},
+			Action: sessionRestoreAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "Print the saved sessions, the last saved first",
+			Action: sessionListAction,
+		},
+	}
+}
+
+// saveSession saves the tabs about to be opened as the session named by
+// --save-session, if it is given
+func saveSession(c *cli.Context, news []hnreader.Story) error {
+	name := c.String("save-session")
+	if name == "" {
+		return nil
+	}
+	dir, err := hnreader.SessionsDir()
+	if err != nil {
+		return err
+	}
+	return hnreader.SaveSession(dir, name, news)
+}
+
+// loadSession reads the session of the given name, or the session file at
+// the given path
+func loadSession(arg string) (*hnreader.Session, error) {
+	if strings.HasSuffix(arg, ".json") {
+		dir, file := filepath.Split(arg)
+		return hnreader.LoadSession(dir, strings.TrimSuffix(file, ".json"))
+	}
+	dir, err := hnreader.SessionsDir()
+	if err != nil {
+		return nil, err
+	}
+	return hnreader.LoadSession(dir, arg)
+}
+
+// sessionRestoreAction reopens the tabs of a saved session
+func sessionRestoreAction(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return handleError(errors.New("session restore needs a session name, e.g. hnreader session restore monday"))
+	}
+	session, err := loadSession(c.Args().First())
+	if err != nil {
+		return handleError(err)
+	}
+	if len(session.Stories) == 0 {
+		return handleError(fmt.Errorf("session %q has no tabs", session.Name))
+	}
+	return handleError(openTabs(c, session.Stories))
+}
+
+// sessionListAction prints the saved sessions with their number of tabs
+func sessionListAction(c *cli.Context) error {
+	dir, err := hnreader.SessionsDir()
+	if err != nil {
+		return handleError(err)
+	}
+	sessions, err := hnreader.Sessions(dir)
+	if err != nil {
+		return handleError(err)
+	}
+	if len(sessions) == 0 {
+		return handleError(errors.New("no sessions saved yet, save one with hnreader r --save-session <name>"))
+	}
+	for _, session := range sessions {
+		fmt.Printf("%s %s %s\n", yellow(session.Name), blue(fmt.Sprintf("%d tabs", len(session.Stories))),
+			session.SavedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
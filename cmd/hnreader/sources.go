@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Bunchhieng/hnreader"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// prepareSources loads the configuration and registers the sources it and
+// the plugins declare
+func prepareSources(c *cli.Context) (*hnreader.Config, error) {
+	config, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := hnreader.RegisterScrapers(config.Sources); err != nil {
+		return nil, err
+	}
+	hnreader.RegisterExecPlugins()
+	if dir, err := hnreader.PluginDir(); err == nil {
+		// a broken plugin shouldn't keep the other sources from working
+		handleError(hnreader.LoadGoPlugins(dir))
+	}
+	return config, nil
+}
+
+// sourcesAction prints every registered source
+func sourcesAction(c *cli.Context) error {
+	if _, err := prepareSources(c); err != nil {
+		return handleError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, src := range hnreader.Sources() {
+		fmt.Fprintf(w, "%s\t%s\n", blue(src.Name), src.Description)
+		if len(src.Flags) > 0 {
+			fmt.Fprintf(w, "\tflags: --%s\n", strings.Join(src.Flags, ", --"))
+		}
+		if src.Credentials != "" {
+			fmt.Fprintf(w, "\tconfiguration: %s\n", src.Credentials)
+		}
+	}
+	return w.Flush()
+}
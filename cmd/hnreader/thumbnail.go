@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"time"
+)
+
+// Graphics protocols the thumbnails can be drawn with
+const (
+	graphicsKitty = "kitty"
+	graphicsITerm = "iterm"
+	graphicsSixel = "sixel"
+	graphicsOff   = "off"
+)
+
+// Room taken by a thumbnail, in cells and in pixels as cells are usually
+// at least 8 by 16 pixels large
+const (
+	thumbnailRows   = 8
+	thumbnailWidth  = 24 * 8
+	thumbnailHeight = thumbnailRows * 16
+)
+
+// thumbnailTop is the line of the screen thumbnails are drawn on, under the
+// header, the previewed story and the rule
+const thumbnailTop = 4
+
+// thumbnailDelay is the time given to the renderer to paint the room left
+// for a thumbnail before it is drawn
+const thumbnailDelay = 50 * time.Millisecond
+
+// kittyDelete removes every image drawn with the kitty protocol
+const kittyDelete = "\x1b_Ga=d,q=2\x1b\\"
+
+// kittyChunk is the largest payload of a single kitty escape sequence
+const kittyChunk = 4096
+
+// graphicsProtocols lists the values of the --thumbnails flag
+var graphicsProtocols = []string{graphicsKitty, graphicsITerm, graphicsSixel, graphicsOff}
+
+// detectGraphics guesses the graphics protocol of the terminal from its
+// environment, returning "" when it has none hnreader knows about
+func detectGraphics(getenv func(string) string) string {
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return graphicsKitty
+	case program == "iTerm.app" || program == "WezTerm":
+		return graphicsITerm
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "mlterm"):
+		return graphicsSixel
+	}
+	return ""
+}
+
+// isGraphicsProtocol tells whether protocol is one of graphicsProtocols
+func isGraphicsProtocol(protocol string) bool {
+	for _, p := range graphicsProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeThumbnail returns the escape sequence drawing img with protocol at
+// the cursor, shrunk to fit the room of a thumbnail
+func encodeThumbnail(protocol string, img image.Image) (string, error) {
+	img = fit(img, thumbnailWidth, thumbnailHeight)
+	switch protocol {
+	case graphicsKitty, graphicsITerm:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return "", err
+		}
+		if protocol == graphicsKitty {
+			return encodeKitty(buf.Bytes()), nil
+		}
+		size := img.Bounds().Size()
+		return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%dpx;height=%dpx:%s\a",
+			buf.Len(), size.X, size.Y, base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+	case graphicsSixel:
+		return encodeSixel(img), nil
+	}
+	return "", fmt.Errorf("unknown graphics protocol %q (one of %s)", protocol, strings.Join(graphicsProtocols, ", "))
+}
+
+// encodeKitty sends the PNG image in chunks, without moving the cursor and
+// without the terminal answering, which would be read as keys
+func encodeKitty(data []byte) string {
+	payload := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for start := 0; start < len(payload); start += kittyChunk {
+		end := start + kittyChunk
+		more := 1
+		if end >= len(payload) {
+			end, more = len(payload), 0
+		}
+		if start == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,C=1,q=2,m=%d;%s\x1b\\", more, payload[start:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, payload[start:end])
+		}
+	}
+	return b.String()
+}
+
+// encodeSixel draws img with the 216 colors of a 6x6x6 cube, six rows of
+// pixels at a time
+func encodeSixel(img image.Image) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y*width+x] = level(r)*36 + level(g)*6 + level(b)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bPq\"1;1;%d;%d", width, height)
+	for i := 0; i < 216; i++ {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+	}
+	for top := 0; top < height; top += 6 {
+		// the colors of the band, in the order they appear
+		var colors []int
+		seen := make(map[int]bool)
+		for y := top; y < top+6 && y < height; y++ {
+			for _, color := range pixels[y*width : (y+1)*width] {
+				if !seen[color] {
+					seen[color] = true
+					colors = append(colors, color)
+				}
+			}
+		}
+
+		for _, color := range colors {
+			fmt.Fprintf(&b, "#%d", color)
+			var run byte
+			count := 0
+			for x := 0; x < width; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					if pixels[(top+dy)*width+x] == color {
+						bits |= 1 << uint(dy)
+					}
+				}
+				if sixel := byte(63 + bits); sixel != run {
+					writeSixels(&b, run, count)
+					run, count = sixel, 0
+				}
+				count++
+			}
+			writeSixels(&b, run, count)
+			// back to the start of the band for the next color
+			b.WriteByte('$')
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// writeSixels writes count times the sixel, as a repeat when it is shorter
+func writeSixels(b *strings.Builder, sixel byte, count int) {
+	if count > 3 {
+		fmt.Fprintf(b, "!%d%c", count, sixel)
+		return
+	}
+	for i := 0; i < count; i++ {
+		b.WriteByte(sixel)
+	}
+}
+
+// level rounds a color component to one of the 6 levels of the sixel palette
+func level(v uint32) int {
+	return int((v*5 + 0x7fff) / 0xffff)
+}
+
+// fit shrinks img to fit within width by height pixels, keeping its aspect
+// ratio. Smaller images are left as they are.
+func fit(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= width && h <= height || w == 0 || h == 0 {
+		return img
+	}
+	if w*height > h*width {
+		height = h * width / w
+	} else {
+		width = w * height / h
+	}
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+
+	// nearest neighbour scaling is plenty for a thumbnail
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*w/width, bounds.Min.Y+y*h/height))
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/Bunchhieng/hnreader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectGraphics(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected string
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, graphicsKitty},
+		{map[string]string{"TERM": "xterm-256color", "KITTY_WINDOW_ID": "1"}, graphicsKitty},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, graphicsITerm},
+		{map[string]string{"TERM": "foot"}, graphicsSixel},
+		{map[string]string{"TERM": "xterm-256color"}, ""},
+	}
+	for _, test := range tests {
+		getenv := func(name string) string { return test.env[name] }
+		assert.Equal(t, test.expected, detectGraphics(getenv), "%v", test.env)
+	}
+}
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 192, 48), fit(image.NewGray(image.Rect(0, 0, 400, 100)), 192, 128).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 64, 128), fit(image.NewGray(image.Rect(0, 0, 100, 200)), 192, 128).Bounds())
+	small := image.NewGray(image.Rect(0, 0, 10, 10))
+	assert.Equal(t, small, fit(small, 192, 128))
+}
+
+func TestEncodeSixel(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 5, 2))
+	for x := 0; x < 5; x++ {
+		img.Set(x, 0, color.RGBA{255, 0, 0, 255})
+		img.Set(x, 1, color.RGBA{0, 0, 255, 255})
+	}
+	sixel := encodeSixel(img)
+	assert.True(t, strings.HasPrefix(sixel, "\x1bPq\"1;1;5;2#0;2;0;0;0"))
+	// red on the first row of the band and blue on the second, repeated
+	assert.True(t, strings.HasSuffix(sixel, "#180!5@$#5!5A$-\x1b\\"), sixel)
+}
+
+func TestEncodeKitty(t *testing.T) {
+	kitty := encodeKitty(make([]byte, 3100))
+	assert.Equal(t, 2, strings.Count(kitty, "\x1b_G"))
+	assert.True(t, strings.HasPrefix(kitty, "\x1b_Ga=T,f=100,C=1,q=2,m=1;"))
+	assert.Contains(t, kitty, "\x1b\\\x1b_Gm=0;")
+
+	_, err := encodeThumbnail("braille", image.NewGray(image.Rect(0, 0, 1, 1)))
+	assert.NotNil(t, err)
+}
+
+func TestTUIModelThumbnail(t *testing.T) {
+	m := newTUIModel([]hnreader.Story{{Title: "First", URL: "https://example.com/1"}})
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	m.graphics = graphicsSixel
+	m.fetchArticle = func(url string) (hnreader.Article, error) {
+		return hnreader.Article{Paragraphs: []string{"Text"}, Image: "https://example.com/1.png"}, nil
+	}
+	m.fetchThumbnail = func(url string) (string, error) {
+		assert.Equal(t, "https://example.com/1.png", url)
+		return "<image>", nil
+	}
+	var drawn []string
+	m.draw = func(seq string) {
+		drawn = append(drawn, seq)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	_, cmd = m.Update(cmd())
+	_, cmd = m.Update(cmd())
+	cmd()
+	assert.Equal(t, []string{"\x1b7\x1b[4;1H<image>\x1b8"}, drawn)
+	assert.Equal(t, 20-5-thumbnailRows, m.previewRows())
+	assert.Contains(t, m.View(), strings.Repeat("\n", thumbnailRows+1)+"Text")
+
+	// closing the preview clears the screen
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, m.preview)
+	assert.NotNil(t, cmd)
+}
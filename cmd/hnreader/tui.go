@@ -0,0 +1,758 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Bunchhieng/hnreader"
+	tea "github.com/charmbracelet/bubbletea"
+	cli "gopkg.in/urfave/cli.v2"
+)
+
+// tuiHelp is the key reminder shown under the stories
+const tuiHelp = "↑/↓ move • / filter • space preview • x select • o open • c comments • b bookmark • m mark read • p read later • q quit"
+
+// tuiFilterHelp replaces tuiHelp while the filter is typed
+const tuiFilterHelp = "type to filter • ↑/↓ move • enter done • esc clear"
+
+// tuiPreviewHelp replaces tuiHelp while an article is previewed
+const tuiPreviewHelp = "↑/↓ scroll • space close • o open • c comments • b bookmark • m mark read • p read later • q quit"
+
+// getTUIFlags return the flags of the tui command
+func getTUIFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.UintFlag{
+			Name:    "number",
+			Value:   30,
+			Aliases: []string{"n"},
+			Usage:   "Specify number of stories\t",
+		},
+		&cli.StringFlag{
+			Name:    "browser",
+			Value:   "",
+			Aliases: []string{"b"},
+			Usage:   "Specify browser\t",
+		},
+		yesFlag(),
+		&cli.StringFlag{
+			Name:  "thumbnails",
+			Usage: fmt.Sprintf("Graphics protocol of the thumbnails shown in previews (one of %s), guessed from the terminal by default\t", strings.Join(graphicsProtocols, ", ")),
+		},
+		&cli.BoolFlag{
+			Name:  "reading-time",
+			Usage: "Fetch each article to tell how long it takes to read\t",
+		},
+	}
+	flags = append(flags, getSourceFlags()...)
+	flags = append(flags, getFilterFlags()...)
+	return append(flags, getNetworkFlags()...)
+}
+
+// tuiAction lets the user pick the stories to open from a list
+func tuiAction(c *cli.Context) error {
+	src, count, err := newSourceFetcher(c, "number")
+	if err != nil {
+		return handleError(err)
+	}
+	graphics := c.String("thumbnails")
+	switch {
+	case !c.IsSet("thumbnails"):
+		graphics = detectGraphics(os.Getenv)
+	case graphics == graphicsOff:
+		graphics = ""
+	case !isGraphicsProtocol(graphics):
+		return handleError(fmt.Errorf("unknown thumbnails %q (one of %s)", graphics, strings.Join(graphicsProtocols, ", ")))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	news, err := fetchStories(ctx, c, src, count)
+	handleError(err)
+	if len(news) == 0 {
+		return nil
+	}
+	if err := estimateReadingTimes(ctx, c, news); err != nil {
+		return handleError(err)
+	}
+
+	bookmarks, err := hnreader.BookmarksFile()
+	if err != nil {
+		return handleError(err)
+	}
+	// the stories are shown as unread when the history can't be read
+	read, _ := readURLs()
+	config, err := loadConfig(c)
+	if err != nil {
+		return handleError(err)
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return handleError(err)
+	}
+
+	browser := c.String("browser")
+	m := newTUIModel(news)
+	if !c.Bool("yes") {
+		m.confirmAbove = config.ConfirmThreshold()
+	}
+	for i, story := range news {
+		m.read[i] = hnreader.IsRead(read, story)
+	}
+	m.open = func(pages []hnreader.Story) error {
+		var opened []hnreader.Story
+		defer func() { recordOpened(opened) }()
+		for _, page := range pages {
+			if err := hnreader.Open(page.URL, browser); err != nil {
+				return err
+			}
+			opened = append(opened, page)
+		}
+		return nil
+	}
+	m.bookmark = func(story hnreader.Story) error {
+		return hnreader.AddBookmark(bookmarks, story)
+	}
+	m.markRead = func(story hnreader.Story) error {
+		return updateHistory(func(h *hnreader.History) error {
+			return h.MarkRead([]hnreader.Story{story}, time.Now())
+		})
+	}
+	m.saveForLater = func(story hnreader.Story) error {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		return hnreader.SaveForLater(ctx, client, config.ReadLater, story)
+	}
+	m.fetchArticle = func(url string) (hnreader.Article, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		return hnreader.FetchArticle(ctx, client, url)
+	}
+	m.graphics = graphics
+	m.fetchThumbnail = func(url string) (string, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		img, err := hnreader.FetchImage(ctx, client, url)
+		if err != nil {
+			return "", err
+		}
+		return encodeThumbnail(graphics, img)
+	}
+	m.draw = func(seq string) {
+		// let the renderer paint the room left for the image first
+		time.Sleep(thumbnailDelay)
+		os.Stdout.WriteString(seq)
+	}
+
+	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return handleError(err)
+}
+
+// tuiModel is the state of the story picker
+type tuiModel struct {
+	news []hnreader.Story
+	// visible are the indexes of the stories matching the filter
+	visible []int
+	// query is the fuzzy filter, typed while filtering is set
+	query     string
+	filtering bool
+	// cursor is the position in visible of the story under the cursor,
+	// offset the first one shown
+	cursor, offset int
+	// size of the terminal, 0 until it is known
+	width, height int
+	// preview is the article shown under the story, nil when there is none
+	preview *tuiPreview
+	// selected are the indexes of the selected stories
+	selected map[int]bool
+	// read are the indexes of the stories marked as read
+	read map[int]bool
+	// status reports the outcome of the last action
+	status string
+	// confirmAbove is the number of tabs above which opening them must be
+	// confirmed, negative when it never has to be
+	confirmAbove int
+	// pending are the pages waiting for confirmation, nil when there are none
+	pending *tuiPending
+
+	// open opens the pages, the stories with the address to open
+	open         func(pages []hnreader.Story) error
+	bookmark     func(story hnreader.Story) error
+	markRead     func(story hnreader.Story) error
+	saveForLater func(story hnreader.Story) error
+	fetchArticle func(url string) (hnreader.Article, error)
+
+	// graphics is the protocol thumbnails are drawn with, "" for none
+	graphics string
+	// fetchThumbnail returns the escape sequence drawing the image at url
+	fetchThumbnail func(url string) (string, error)
+	// draw writes an escape sequence to the terminal, around the renderer
+	draw func(seq string)
+}
+
+// tuiPending are the pages of stories waiting for the user to confirm
+// opening them
+type tuiPending struct {
+	// indexes are the stories of the pages
+	indexes []int
+	pages   []hnreader.Story
+	// one and many name what is opened in the status
+	one, many string
+}
+
+// tuiPreview is the article previewed in the picker
+type tuiPreview struct {
+	// story is the index of the previewed story
+	story int
+	// article is nil until it is fetched, or when fetching it failed
+	article *hnreader.Article
+	err     error
+	// offset is the first line shown
+	offset int
+	// thumbnail draws the image of the article, "" when there is none
+	thumbnail string
+}
+
+// previewMsg carries the article fetched for the story at index
+type previewMsg struct {
+	story   int
+	article hnreader.Article
+	err     error
+}
+
+// thumbnailMsg carries the thumbnail fetched for the story at index
+type thumbnailMsg struct {
+	story     int
+	thumbnail string
+	err       error
+}
+
+// newTUIModel returns a picker of the stories with the cursor on the first
+func newTUIModel(news []hnreader.Story) *tuiModel {
+	m := &tuiModel{news: news, selected: make(map[int]bool), read: make(map[int]bool), confirmAbove: -1}
+	m.filter()
+	return m
+}
+
+// filter shows the stories matching the query and puts the cursor back on
+// the first one
+func (m *tuiModel) filter() {
+	m.visible = m.visible[:0]
+	for i, story := range m.news {
+		if hnreader.FuzzyMatch(m.query, story) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+// Init implements tea.Model
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model. The thumbnail is drawn around the renderer,
+// which must clear the screen once it is gone.
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	thumbnail := m.preview != nil && m.preview.thumbnail != ""
+	model, cmd := m.update(msg)
+	if thumbnail && (m.preview == nil || m.preview.thumbnail == "") {
+		cmd = tea.Batch(cmd, m.clearThumbnail())
+	}
+	return model, cmd
+}
+
+// update handles msg for Update
+func (m *tuiModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+		if m.preview != nil && m.preview.thumbnail != "" {
+			// resizing repaints over it
+			return m, m.drawThumbnail()
+		}
+	case previewMsg:
+		return m, m.showPreview(msg)
+	case thumbnailMsg:
+		return m, m.showThumbnail(msg)
+	case tea.KeyMsg:
+		if pending := m.pending; pending != nil {
+			m.pending = nil
+			switch msg.String() {
+			case "y", "Y":
+				m.openPending(pending)
+			default:
+				m.status = "Not opened"
+			}
+			return m, nil
+		}
+		if m.filtering {
+			return m, m.updateFilter(msg)
+		}
+		if m.preview != nil {
+			if cmd, done := m.updatePreview(msg); done {
+				return m, cmd
+			}
+		}
+
+		switch msg.String() {
+		case "/":
+			m.filtering = true
+			m.preview = nil
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			m.move(-1)
+		case "down", "j":
+			m.move(1)
+		case "pgup":
+			m.move(-m.rows())
+		case "pgdown":
+			m.move(m.rows())
+		case "home", "g":
+			m.move(-len(m.visible))
+		case "end", "G":
+			m.move(len(m.visible))
+		case " ":
+			return m, m.startPreview()
+		case "x":
+			if len(m.visible) == 0 {
+				break
+			}
+			i := m.visible[m.cursor]
+			if m.selected[i] {
+				delete(m.selected, i)
+			} else {
+				m.selected[i] = true
+			}
+		case "enter", "o":
+			m.openTargets(func(story hnreader.Story) string { return story.URL }, "story", "stories")
+		case "c":
+			m.openTargets(func(story hnreader.Story) string { return story.CommentsURL }, "discussion", "discussions")
+		case "b":
+			m.applyTargets(func(i int) error {
+				return m.bookmark(m.news[i])
+			}, "Bookmarked %s")
+		case "m":
+			m.applyTargets(func(i int) error {
+				if err := m.markRead(m.news[i]); err != nil {
+					return err
+				}
+				m.read[i] = true
+				return nil
+			}, "Marked %s as read")
+		case "p":
+			m.applyTargets(func(i int) error {
+				return m.saveForLater(m.news[i])
+			}, "Saved %s for later")
+		}
+	}
+	return m, nil
+}
+
+// startPreview fetches the article of the story under the cursor to show it
+func (m *tuiModel) startPreview() tea.Cmd {
+	if len(m.visible) == 0 {
+		return nil
+	}
+	i := m.visible[m.cursor]
+	m.preview = &tuiPreview{story: i}
+
+	url, fetch := m.news[i].URL, m.fetchArticle
+	return func() tea.Msg {
+		article, err := fetch(url)
+		return previewMsg{story: i, article: article, err: err}
+	}
+}
+
+// showPreview shows the fetched article, unless its preview was closed, and
+// fetches its thumbnail when the terminal can draw it
+func (m *tuiModel) showPreview(msg previewMsg) tea.Cmd {
+	if m.preview == nil || m.preview.story != msg.story {
+		return nil
+	}
+	if msg.err != nil {
+		m.preview.err = msg.err
+		return nil
+	}
+	m.preview.article = &msg.article
+	if m.graphics == "" || msg.article.Image == "" {
+		return nil
+	}
+
+	url, fetch := msg.article.Image, m.fetchThumbnail
+	return func() tea.Msg {
+		thumbnail, err := fetch(url)
+		return thumbnailMsg{story: msg.story, thumbnail: thumbnail, err: err}
+	}
+}
+
+// showThumbnail draws the fetched thumbnail above the previewed article. A
+// missing image is not worth reporting, the article is shown without it.
+func (m *tuiModel) showThumbnail(msg thumbnailMsg) tea.Cmd {
+	if m.preview == nil || m.preview.story != msg.story || msg.err != nil {
+		return nil
+	}
+	m.preview.thumbnail = msg.thumbnail
+	m.scrollPreview(0)
+	return m.drawThumbnail()
+}
+
+// drawThumbnail draws the thumbnail of the preview in the room View leaves
+// for it under the rule
+func (m *tuiModel) drawThumbnail() tea.Cmd {
+	seq := fmt.Sprintf("\x1b7\x1b[%d;1H%s\x1b8", thumbnailTop, m.preview.thumbnail)
+	draw := m.draw
+	return func() tea.Msg {
+		draw(seq)
+		return nil
+	}
+}
+
+// clearThumbnail removes the thumbnail, which the renderer doesn't know of
+func (m *tuiModel) clearThumbnail() tea.Cmd {
+	if m.graphics != graphicsKitty {
+		return tea.ClearScreen
+	}
+	// kitty keeps its images apart from the text
+	draw := m.draw
+	return tea.Batch(tea.ClearScreen, func() tea.Msg {
+		draw(kittyDelete)
+		return nil
+	})
+}
+
+// updatePreview scrolls and closes the preview. It tells whether it took
+// the key, leaving the ones acting on the story to Update.
+func (m *tuiModel) updatePreview(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter", "o", "c", "b", "m", "p", "q", "ctrl+c":
+		return nil, false
+	case " ", "esc":
+		m.preview = nil
+	case "up", "k":
+		m.scrollPreview(-1)
+	case "down", "j":
+		m.scrollPreview(1)
+	case "pgup":
+		m.scrollPreview(-m.previewRows())
+	case "pgdown":
+		m.scrollPreview(m.previewRows())
+	}
+	return nil, true
+}
+
+// scrollPreview scrolls the preview by delta lines, staying within the text
+func (m *tuiModel) scrollPreview(delta int) {
+	m.preview.offset += delta
+	if last := len(m.previewLines()) - m.previewRows(); m.preview.offset > last {
+		m.preview.offset = last
+	}
+	if m.preview.offset < 0 {
+		m.preview.offset = 0
+	}
+}
+
+// previewRows returns how many lines of the preview fit on the screen
+func (m *tuiModel) previewRows() int {
+	if m.height == 0 {
+		return len(m.previewLines())
+	}
+	// the header, the story, the rule, the thumbnail and the two lines of
+	// the footer take the rest
+	rows := m.height - 5
+	if m.preview.thumbnail != "" {
+		rows -= thumbnailRows
+	}
+	if rows > 0 {
+		return rows
+	}
+	return 1
+}
+
+// previewLines returns the text of the preview wrapped to the terminal
+func (m *tuiModel) previewLines() []string {
+	switch {
+	case m.preview.err != nil:
+		return []string{m.preview.err.Error()}
+	case m.preview.article == nil:
+		return []string{"Fetching the article..."}
+	case len(m.preview.article.Paragraphs) == 0:
+		return []string{"No readable text found"}
+	}
+
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	var lines []string
+	for i, paragraph := range m.preview.article.Paragraphs {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, wrap(paragraph, width)...)
+	}
+	return lines
+}
+
+// wrap breaks text into lines of at most width runes, between words unless
+// a word is longer than that
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// updateFilter edits the query as it is typed
+func (m *tuiModel) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering, m.query = false, ""
+		m.filter()
+	case tea.KeyUp:
+		m.move(-1)
+	case tea.KeyDown:
+		m.move(1)
+	case tea.KeyBackspace:
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+			m.filter()
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+		m.filter()
+	}
+	return nil
+}
+
+// targets returns the selected stories, or the one under the cursor when
+// none is selected
+func (m *tuiModel) targets() []int {
+	if len(m.selected) == 0 {
+		if len(m.visible) == 0 {
+			return nil
+		}
+		return []int{m.visible[m.cursor]}
+	}
+	var indexes []int
+	for i := range m.selected {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// openTargets opens the address link gives for each target story, marking
+// them as read, and clears the selection. Opening more than confirmAbove
+// tabs waits for the user to confirm.
+func (m *tuiModel) openTargets(link func(hnreader.Story) string, one, many string) {
+	pending := &tuiPending{one: one, many: many}
+	for _, i := range m.targets() {
+		page := m.news[i]
+		if page.URL = link(page); page.URL != "" {
+			pending.indexes = append(pending.indexes, i)
+			pending.pages = append(pending.pages, page)
+		}
+	}
+	if m.confirmAbove >= 0 && len(pending.pages) > m.confirmAbove {
+		m.pending = pending
+		m.status = fmt.Sprintf("About to open these %d tabs, continue? [y/N]", len(pending.pages))
+		return
+	}
+	m.openPending(pending)
+}
+
+// openPending opens the pages waiting to be opened
+func (m *tuiModel) openPending(pending *tuiPending) {
+	if len(pending.pages) > 0 {
+		if err := m.open(pending.pages); err != nil {
+			m.status = err.Error()
+			return
+		}
+	}
+	for _, i := range pending.indexes {
+		m.read[i] = true
+	}
+
+	m.selected = make(map[int]bool)
+	switch len(pending.pages) {
+	case 0:
+		m.status = fmt.Sprintf("No %s to open", pending.one)
+	case 1:
+		m.status = fmt.Sprintf("Opened 1 %s", pending.one)
+	default:
+		m.status = fmt.Sprintf("Opened %d %s", len(pending.pages), pending.many)
+	}
+}
+
+// applyTargets calls action with the index of each target story and clears
+// the selection. done reports the outcome, formatted with the number of
+// stories.
+func (m *tuiModel) applyTargets(action func(i int) error, done string) {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return
+	}
+	for _, i := range targets {
+		if err := action(i); err != nil {
+			m.status = err.Error()
+			return
+		}
+	}
+
+	m.selected = make(map[int]bool)
+	if len(targets) == 1 {
+		m.status = fmt.Sprintf(done, "1 story")
+	} else {
+		m.status = fmt.Sprintf(done, fmt.Sprintf("%d stories", len(targets)))
+	}
+}
+
+// rows returns how many stories fit on the screen
+func (m *tuiModel) rows() int {
+	if m.height == 0 {
+		return len(m.visible)
+	}
+	// the header and the two lines of the footer take the rest
+	if rows := m.height - 3; rows > 0 {
+		return rows
+	}
+	return 1
+}
+
+// move moves the cursor by delta stories, staying within the list
+func (m *tuiModel) move(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scroll()
+}
+
+// scroll keeps the cursor on the screen
+func (m *tuiModel) scroll() {
+	rows := m.rows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// View implements tea.Model
+func (m *tuiModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d stories, %d selected", blue(hnreader.AppName), len(m.visible), len(m.selected))
+	if m.filtering || m.query != "" {
+		fmt.Fprintf(&b, " %s", yellow("/"+m.query))
+	}
+	b.WriteString("\n")
+
+	help := tuiHelp
+	switch {
+	case m.filtering:
+		help = tuiFilterHelp
+	case m.preview != nil:
+		help = tuiPreviewHelp
+		b.WriteString(m.row(m.cursor) + "\n")
+		b.WriteString(strings.Repeat("─", m.ruleWidth()) + "\n")
+		if m.preview.thumbnail != "" {
+			b.WriteString(strings.Repeat("\n", thumbnailRows))
+		}
+		lines := m.previewLines()
+		end := m.preview.offset + m.previewRows()
+		if end > len(lines) {
+			end = len(lines)
+		}
+		for _, line := range lines[m.preview.offset:end] {
+			b.WriteString(line + "\n")
+		}
+		fmt.Fprintf(&b, "%s\n%s", m.status, help)
+		return b.String()
+	}
+
+	end := m.offset + m.rows()
+	if end > len(m.visible) {
+		end = len(m.visible)
+	}
+	for row := m.offset; row < end; row++ {
+		b.WriteString(m.row(row) + "\n")
+	}
+
+	fmt.Fprintf(&b, "%s\n%s", m.status, help)
+	return b.String()
+}
+
+// ruleWidth returns the width of the rule above the preview
+func (m *tuiModel) ruleWidth() int {
+	if m.width == 0 {
+		return 80
+	}
+	return m.width
+}
+
+// row renders the visible story at row
+func (m *tuiModel) row(row int) string {
+	i := m.visible[row]
+	story := m.news[i]
+	cursor, check := "  ", "[ ]"
+	if row == m.cursor {
+		cursor = yellow("> ")
+	}
+	if m.selected[i] {
+		check = "[x]"
+	}
+
+	var details []string
+	if story.HasScore {
+		details = append(details, fmt.Sprintf("%d points", story.Score))
+	}
+	if story.Source != "" {
+		details = append(details, story.Source)
+	}
+	if !story.PublishedAt.IsZero() {
+		details = append(details, ago(story.PublishedAt, time.Now()))
+	}
+	if story.ReadingMinutes != 0 {
+		details = append(details, fmt.Sprintf("%d min read", story.ReadingMinutes))
+	}
+	if m.read[i] {
+		details = append(details, "read")
+	}
+	line := fmt.Sprintf("%s%s %2d. %s", cursor, check, i+1, story.Title)
+	if domain := story.Domain(); domain != "" {
+		line += " (" + domain + ")"
+	}
+	if len(story.Tags) > 0 {
+		line += " " + yellow("["+strings.Join(story.Tags, ", ")+"]")
+	}
+	if len(details) > 0 {
+		line += " " + blue("("+strings.Join(details, ", ")+")")
+	}
+	return line
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Bunchhieng/hnreader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTUIModel(t *testing.T) {
+	news := []hnreader.Story{
+		{Title: "First", URL: "https://example.com/1", CommentsURL: "https://news.ycombinator.com/item?id=1"},
+		{Title: "Second", URL: "https://example.com/2"},
+		{Title: "Third", URL: "https://example.com/3"},
+	}
+	var opened []string
+	var bookmarked []hnreader.Story
+	m := newTUIModel(news)
+	m.open = func(pages []hnreader.Story) error {
+		for _, page := range pages {
+			opened = append(opened, page.URL)
+		}
+		return nil
+	}
+	m.bookmark = func(story hnreader.Story) error {
+		bookmarked = append(bookmarked, story)
+		return nil
+	}
+	press := func(keys ...string) {
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			switch key {
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+			}
+			m.Update(msg)
+		}
+	}
+
+	// without a selection the story under the cursor is used
+	press("c")
+	assert.Equal(t, []string{"https://news.ycombinator.com/item?id=1"}, opened)
+	press("down", "b")
+	assert.Equal(t, []hnreader.Story{news[1]}, bookmarked)
+
+	press("x", "down", "x", "enter")
+	assert.Equal(t, []string{"https://news.ycombinator.com/item?id=1", "https://example.com/2", "https://example.com/3"}, opened)
+	assert.Empty(t, m.selected)
+	assert.Equal(t, "Opened 2 stories", m.status)
+	assert.Equal(t, map[int]bool{0: true, 1: true, 2: true}, m.read)
+
+	// the cursor stays within the list
+	press("down", "down")
+	assert.Equal(t, 2, m.cursor)
+}
+
+func TestTUIModelTriage(t *testing.T) {
+	news := []hnreader.Story{
+		{Title: "First", URL: "https://example.com/1"},
+		{Title: "Second", URL: "https://example.com/2"},
+	}
+	var read, later []hnreader.Story
+	m := newTUIModel(news)
+	m.markRead = func(story hnreader.Story) error {
+		read = append(read, story)
+		return nil
+	}
+	m.saveForLater = func(story hnreader.Story) error {
+		if story.URL == news[1].URL {
+			return errors.New("instapaper: 500 Internal Server Error")
+		}
+		later = append(later, story)
+		return nil
+	}
+	press := func(key string) {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	press("x")
+	press("j")
+	press("x")
+	press("m")
+	assert.Equal(t, news, read)
+	assert.Equal(t, map[int]bool{0: true, 1: true}, m.read)
+	assert.Equal(t, "Marked 2 stories as read", m.status)
+	assert.Contains(t, m.row(0), "read")
+
+	press("k")
+	press("p")
+	assert.Equal(t, news[:1], later)
+	assert.Equal(t, "Saved 1 story for later", m.status)
+	press("j")
+	press("p")
+	assert.Equal(t, "instapaper: 500 Internal Server Error", m.status)
+}
+
+func TestTUIModelConfirm(t *testing.T) {
+	news := []hnreader.Story{
+		{Title: "First", URL: "https://example.com/1"},
+		{Title: "Second", URL: "https://example.com/2"},
+	}
+	var opened []string
+	m := newTUIModel(news)
+	m.confirmAbove = 1
+	m.open = func(pages []hnreader.Story) error {
+		for _, page := range pages {
+			opened = append(opened, page.URL)
+		}
+		return nil
+	}
+	press := func(key string) {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	// a single tab opens right away
+	press("o")
+	assert.Equal(t, []string{"https://example.com/1"}, opened)
+
+	press("x")
+	press("j")
+	press("x")
+	press("o")
+	assert.Equal(t, "About to open these 2 tabs, continue? [y/N]", m.status)
+	press("n")
+	assert.Equal(t, "Not opened", m.status)
+	assert.Len(t, opened, 1)
+
+	// the selection is kept for another try
+	press("o")
+	press("y")
+	assert.Equal(t, []string{"https://example.com/1", "https://example.com/1", "https://example.com/2"}, opened)
+	assert.Equal(t, "Opened 2 stories", m.status)
+}
+
+func TestTUIModelScroll(t *testing.T) {
+	m := newTUIModel(make([]hnreader.Story, 10))
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 5})
+	assert.Equal(t, 2, m.rows())
+
+	m.move(3)
+	assert.Equal(t, 2, m.offset)
+	m.move(-3)
+	assert.Equal(t, 0, m.offset)
+}
+
+func TestTUIModelFilter(t *testing.T) {
+	news := []hnreader.Story{
+		{Title: "Go 1.11 released", URL: "https://blog.golang.org/go1.11"},
+		{Title: "Async Rust", URL: "https://example.com/rust"},
+		{Title: "Rust 2018", URL: "https://blog.rust-lang.org/2018"},
+	}
+	var opened []string
+	m := newTUIModel(news)
+	m.open = func(pages []hnreader.Story) error {
+		for _, page := range pages {
+			opened = append(opened, page.URL)
+		}
+		return nil
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "rust" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Equal(t, []int{1, 2}, m.visible)
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	for _, r := range "asn" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Equal(t, "rust asn", m.query)
+	assert.Equal(t, []int{1}, m.visible)
+
+	// enter leaves the filter, a second one opens the story
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, []string{"https://example.com/rust"}, opened)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.filtering)
+	assert.Len(t, m.visible, 3)
+}
+
+func TestTUIModelPreview(t *testing.T) {
+	news := []hnreader.Story{{Title: "First", URL: "https://example.com/1"}, {Title: "Second", URL: "https://example.com/2"}}
+	m := newTUIModel(news)
+	m.fetchArticle = func(url string) (hnreader.Article, error) {
+		assert.Equal(t, "https://example.com/2", url)
+		return hnreader.Article{Paragraphs: []string{"one two three four", "five"}}, nil
+	}
+	m.Update(tea.WindowSizeMsg{Width: 9, Height: 8})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.NotNil(t, m.preview)
+	assert.Equal(t, []string{"Fetching the article..."}, m.previewLines())
+
+	m.Update(cmd())
+	assert.Equal(t, []string{"one two", "three", "four", "", "five"}, m.previewLines())
+
+	// the arrows scroll the preview instead of moving the cursor
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 2, m.preview.offset)
+	assert.Equal(t, 1, m.cursor)
+
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Nil(t, m.preview)
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"the quick", "brown fox", "jumps"}, wrap("the quick brown  fox jumps", 10))
+	assert.Equal(t, []string{"a", "verylongword", "b"}, wrap("a verylongword b", 5))
+	assert.Nil(t, wrap(" ", 5))
+}
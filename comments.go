@@ -0,0 +1,159 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Comment is a comment of a Hacker News discussion, with its replies.
+type Comment struct {
+	ID     int
+	Author string
+	// Text is the comment as plain text, paragraphs separated by blank
+	// lines. It is empty for deleted comments.
+	Text        string
+	PublishedAt time.Time
+	Replies     []Comment
+}
+
+// Count returns the number of replies to the comment, including replies to
+// replies.
+func (c Comment) Count() int {
+	n := len(c.Replies)
+	for _, reply := range c.Replies {
+		n += reply.Count()
+	}
+	return n
+}
+
+// Discussion is a Hacker News story with its comments.
+type Discussion struct {
+	Story Story
+	// Text is the body of Ask HN and similar posts
+	Text     string
+	Comments []Comment
+}
+
+// algoliaItem is an item of the Algolia items endpoint, which returns a
+// whole discussion at once
+type algoliaItem struct {
+	ID         int           `json:"id"`
+	Type       string        `json:"type"`
+	Author     string        `json:"author"`
+	Title      string        `json:"title"`
+	URL        string        `json:"url"`
+	Text       string        `json:"text"`
+	Points     int           `json:"points"`
+	CreatedAtI int64         `json:"created_at_i"`
+	Children   []algoliaItem `json:"children"`
+}
+
+// comment converts the item and its children into a Comment
+func (item algoliaItem) comment() Comment {
+	c := Comment{
+		ID:          item.ID,
+		Author:      item.Author,
+		Text:        htmlToText(item.Text),
+		PublishedAt: time.Unix(item.CreatedAtI, 0).UTC(),
+	}
+	for _, child := range item.Children {
+		c.Replies = append(c.Replies, child.comment())
+	}
+	return c
+}
+
+// ParseItemID reads a Hacker News item ID, given as is or as the address
+// of its discussion.
+func ParseItemID(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		return id, nil
+	}
+
+	u, err := url.Parse(value)
+	if err == nil {
+		if id, err := strconv.Atoi(u.Query().Get("id")); err == nil && id > 0 {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a Hacker News item ID or URL", value)
+}
+
+// FetchDiscussion gets the Hacker News story with the given ID and its
+// comments from the Algolia API.
+func FetchDiscussion(ctx context.Context, client *http.Client, id int) (*Discussion, error) {
+	var item algoliaItem
+	if err := getJSON(ctx, client, fmt.Sprintf("%s/items/%d", HackerNewsSearchURL, id), &item); err != nil {
+		return nil, err
+	}
+
+	comments := HackerNewsItemURL + strconv.Itoa(item.ID)
+	d := &Discussion{
+		Story: Story{
+			Title:       item.Title,
+			URL:         item.URL,
+			CommentsURL: comments,
+			Score:       item.Points,
+			HasScore:    true,
+			Author:      item.Author,
+			Source:      "hn",
+			PublishedAt: time.Unix(item.CreatedAtI, 0).UTC(),
+		},
+		Text: htmlToText(item.Text),
+	}
+	if d.Story.URL == "" {
+		d.Story.URL = comments
+	}
+	for _, child := range item.Children {
+		c := child.comment()
+		d.Comments = append(d.Comments, c)
+		d.Story.Comments += 1 + c.Count()
+	}
+	return d, nil
+}
+
+// htmlToText turns the markup of Hacker News comments into plain text.
+// Links are replaced with their full address, which HN shortens in the text.
+func htmlToText(markup string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(markup))
+	inLink := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case html.TextToken:
+			if !inLink {
+				b.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "p":
+				b.WriteString("\n\n")
+			case "br":
+				b.WriteString("\n")
+			case "a":
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					if string(key) == "href" {
+						b.Write(val)
+						inLink = true
+					}
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "a" {
+				inLink = false
+			}
+		}
+	}
+}
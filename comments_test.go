@@ -0,0 +1,54 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseItemID(t *testing.T) {
+	for _, value := range []string{"18103645", " 18103645\n", "https://news.ycombinator.com/item?id=18103645"} {
+		id, err := ParseItemID(value)
+		assert.Nil(t, err)
+		assert.Equal(t, 18103645, id)
+	}
+
+	for _, value := range []string{"", "-1", "https://news.ycombinator.com/news"} {
+		_, err := ParseItemID(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	assert.Equal(t, "It&#x27;s <fine>\n\nSee https://example.com/a/long/path for more",
+		htmlToText(`It&amp;#x27;s &lt;fine&gt;<p>See <a href="https:&#x2F;&#x2F;example.com&#x2F;a&#x2F;long&#x2F;path" rel="nofollow">https://example.com/a/lo...</a> for more`))
+}
+
+func TestFetchDiscussion(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/items/42", r.URL.Path)
+		w.Write([]byte(`{"id": 42, "type": "story", "author": "pg", "title": "Ask HN: Hello?", "url": null, "text": "<i>Hi</i>", "points": 10, "created_at_i": 1538492645,
+"children": [
+	{"id": 43, "type": "comment", "author": "dang", "text": "First", "created_at_i": 1538492700, "children": [
+		{"id": 44, "type": "comment", "author": null, "text": null, "children": []}
+	]},
+	{"id": 45, "type": "comment", "author": "tptacek", "text": "Second", "children": []}
+]}`))
+	}))
+	defer done()
+
+	d, err := FetchDiscussion(context.Background(), client, 42)
+	assert.Nil(t, err)
+	assert.Equal(t, "Ask HN: Hello?", d.Story.Title)
+	assert.Equal(t, "https://news.ycombinator.com/item?id=42", d.Story.URL)
+	assert.Equal(t, 3, d.Story.Comments)
+	assert.Equal(t, "Hi", d.Text)
+
+	assert.Len(t, d.Comments, 2)
+	assert.Equal(t, "dang", d.Comments[0].Author)
+	assert.Equal(t, 1, d.Comments[0].Count())
+	assert.Equal(t, "", d.Comments[0].Replies[0].Text)
+	assert.Equal(t, "tptacek", d.Comments[1].Author)
+}
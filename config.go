@@ -0,0 +1,101 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ConfigDir returns the directory holding hnreader's configuration, following
+// the XDG base directory spec on Linux ($XDG_CONFIG_HOME/hnreader).
+func ConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// DefaultConfigPath returns the path of config.json inside ConfigDir.
+func DefaultConfigPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Config is the optional configuration file of hnreader.
+type Config struct {
+	// Reddit holds the credentials used to log into reddit
+	Reddit RedditCredentials `json:"reddit"`
+	// GitHub lists the repositories followed by the github-releases source
+	GitHub GitHubConfig `json:"github"`
+	// NewsAPI holds the key of the newsapi source
+	NewsAPI NewsAPIConfig `json:"newsapi"`
+	// Sources declares additional scraped sources
+	Sources []ScrapeConfig `json:"sources"`
+	// ConfirmAbove is the number of tabs above which opening them must be
+	// confirmed. DefaultConfirmAbove is used when it is zero, a negative
+	// value never asks.
+	ConfirmAbove int `json:"confirm_above"`
+	// ReadLater is the service the tui saves stories to
+	ReadLater ReadLaterConfig `json:"read_later"`
+	// Paywalls lists more domains behind a paywall than PaywalledDomains
+	Paywalls []string `json:"paywalls"`
+	// Blocklist mutes stories for good
+	Blocklist BlocklistConfig `json:"blocklist"`
+}
+
+// BlocklistConfig lists the stories never to be shown or opened.
+type BlocklistConfig struct {
+	// Keywords of the titles and URLs, ignoring case
+	Keywords []string `json:"keywords"`
+	// Domains, including their subdomains
+	Domains []string `json:"domains"`
+}
+
+// DefaultConfirmAbove is the number of tabs opened without confirmation when
+// the configuration doesn't set one
+const DefaultConfirmAbove = 15
+
+// ConfirmThreshold returns the number of tabs above which opening them must
+// be confirmed, or a negative number when it never has to be.
+func (c *Config) ConfirmThreshold() int {
+	if c.ConfirmAbove == 0 {
+		return DefaultConfirmAbove
+	}
+	return c.ConfirmAbove
+}
+
+// GitHubConfig is the GitHub section of the configuration.
+type GitHubConfig struct {
+	// Repos such as "golang/go"
+	Repos []string `json:"repos"`
+}
+
+// LoadConfig reads the configuration at path. A missing file is not an error
+// and yields an empty configuration.
+func LoadConfig(path string) (*Config, error) {
+	config := new(Config)
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return config, nil
+}
+
+// NewsAPIConfig is the NewsAPI.org section of the configuration.
+type NewsAPIConfig struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,37 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	config, err := LoadConfig(filepath.Join(dir, "missing.json"))
+	assert.Nil(t, err)
+	assert.False(t, config.Reddit.Configured())
+
+	path := filepath.Join(dir, "config.json")
+	ioutil.WriteFile(path, []byte(`{"reddit": {"client_id": "id", "client_secret": "secret", "username": "spez", "password": "hunter2"}, "github": {"repos": ["golang/go"]}, "newsapi": {"key": "abc"}}`), 0600)
+	config, err = LoadConfig(path)
+	assert.Nil(t, err)
+	assert.True(t, config.Reddit.Configured())
+	assert.Equal(t, "spez", config.Reddit.Username)
+	assert.Equal(t, []string{"golang/go"}, config.GitHub.Repos)
+	assert.Equal(t, "abc", config.NewsAPI.Key)
+
+	assert.Equal(t, DefaultConfirmAbove, config.ConfirmThreshold())
+	config.ConfirmAbove = -1
+	assert.Equal(t, -1, config.ConfirmThreshold())
+
+	ioutil.WriteFile(path, []byte(`{`), 0600)
+	_, err = LoadConfig(path)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,41 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// DevToURL is the dev.to feed
+const DevToURL = "https://dev.to/feed"
+
+func init() {
+	Register(Source{
+		Name:        "devto",
+		Description: "Latest posts from dev.to, optionally by tag",
+		Flags:       []string{"tags"},
+		New: func(opts Options) Fetcher {
+			return &DevToSource{Client: opts.Client, Tags: opts.Tags}
+		},
+	})
+}
+
+// DevToSource fetches latest stories from https://dev.to/
+type DevToSource struct {
+	Client *http.Client
+	// Tags restricts the posts to these tags, like "go" or "webdev"
+	Tags []string
+}
+
+// Fetch gets news from the Dev.To. With tags, the feed of every tag is read
+// and their posts are interleaved.
+func (l *DevToSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if len(l.Tags) == 0 {
+		return fetchFeed(ctx, l.Client, DevToURL, "devto", count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Tags)-1, len(l.Tags), func(ctx context.Context, i int) ([]Story, error) {
+		return fetchFeed(ctx, l.Client, DevToURL+"/tag/"+url.PathEscape(l.Tags[i]), "devto", count)
+	})
+	return interleave(lists, count), err
+}
@@ -0,0 +1,76 @@
+package hnreader
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+// digestGroup is the stories of one source in a digest
+type digestGroup struct {
+	Source  string
+	Stories []Story
+}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 48em; margin: 2em auto; padding: 0 1em; font: 16px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; background: #fafafa; }
+h1 { font-size: 1.6em; margin-bottom: 0; }
+.date { color: #888; margin-top: 0; }
+h2 { font-size: 1.1em; text-transform: uppercase; letter-spacing: .05em; color: #ff6600; border-bottom: 1px solid #ddd; padding-bottom: .2em; margin-top: 2em; }
+ol { padding-left: 1.5em; }
+li { margin: .6em 0; }
+a { color: #1a0dab; text-decoration: none; }
+a:hover { text-decoration: underline; }
+.meta, .meta a { color: #888; font-size: .85em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="date">{{.Date.Format "Monday, January 2, 2006 15:04"}}</p>
+{{range .Groups}}<h2>{{.Source}}</h2>
+<ol>
+{{range .Stories}}<li><a href="{{.URL}}">{{.Title}}</a>
+{{if or .HasScore .CommentsURL}}<div class="meta">{{if .HasScore}}{{.Score}} points{{end}}{{if .CommentsURL}}{{if .HasScore}} · {{end}}<a href="{{.CommentsURL}}">{{if .Comments}}{{.Comments}} comments{{else}}comments{{end}}</a>{{end}}</div>{{end}}
+</li>
+{{end}}</ol>
+{{end}}</body>
+</html>
+`))
+
+// groupBySource splits the stories by source, keeping the order in which
+// the sources first appear. Stories without a source are grouped under
+// "other".
+func groupBySource(news []Story) []digestGroup {
+	var groups []digestGroup
+	index := make(map[string]int)
+	for _, story := range news {
+		source := story.Source
+		if source == "" {
+			source = "other"
+		}
+		i, ok := index[source]
+		if !ok {
+			i = len(groups)
+			index[source] = i
+			groups = append(groups, digestGroup{Source: source})
+		}
+		groups[i].Stories = append(groups[i].Stories, story)
+	}
+	return groups
+}
+
+// WriteDigest renders the stories as a single HTML page titled title, with
+// the stories of each source under their own heading.
+func WriteDigest(w io.Writer, title string, news []Story) error {
+	return digestTemplate.Execute(w, struct {
+		Title  string
+		Date   time.Time
+		Groups []digestGroup
+	}{title, time.Now(), groupBySource(news)})
+}
@@ -0,0 +1,40 @@
+package hnreader
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBySource(t *testing.T) {
+	groups := groupBySource([]Story{
+		{Title: "a", Source: "hn"},
+		{Title: "b", Source: "reddit"},
+		{Title: "c", Source: "hn"},
+		{Title: "d"},
+	})
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "hn", groups[0].Source)
+	assert.Len(t, groups[0].Stories, 2)
+	assert.Equal(t, "reddit", groups[1].Source)
+	assert.Equal(t, "other", groups[2].Source)
+}
+
+func TestWriteDigest(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteDigest(&buf, "Today's news", []Story{
+		{Title: "<script>alert(1)</script>", URL: "https://example.com/1", Score: 42, HasScore: true, Source: "hn", CommentsURL: "https://news.ycombinator.com/item?id=1", Comments: 7},
+		{Title: "Second", URL: "https://example.com/2", Source: "lobsters", HasScore: true},
+	})
+	assert.Nil(t, err)
+
+	page := buf.String()
+	assert.Contains(t, page, "<title>Today&#39;s news</title>")
+	assert.Contains(t, page, "<h2>hn</h2>")
+	assert.Contains(t, page, "<h2>lobsters</h2>")
+	assert.Contains(t, page, `<a href="https://example.com/1">&lt;script&gt;alert(1)&lt;/script&gt;</a>`)
+	assert.Contains(t, page, "42 points")
+	assert.Contains(t, page, "0 points")
+	assert.Contains(t, page, "7 comments")
+}
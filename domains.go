@@ -0,0 +1,77 @@
+package hnreader
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadDomains reads a list of domain patterns, one per line. Blank lines and
+// lines starting with # are skipped, as are comments after a pattern.
+func LoadDomains(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readDomains(f)
+}
+
+// readDomains reads the domain patterns of a list
+func readDomains(r io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.ToLower(strings.TrimSpace(line)); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns, scanner.Err()
+}
+
+// MatchDomain tells whether the story links to the domain of pattern, like
+// "example.com", or to it and its subdomains when the pattern starts with
+// "*.", like "*.example.com". The www. prefix is ignored.
+func (s Story) MatchDomain(pattern string) bool {
+	host := s.Domain()
+	if host == "" {
+		return false
+	}
+	pattern = strings.ToLower(pattern)
+	if strings.HasPrefix(pattern, "*.") {
+		return s.OnDomain(pattern[2:])
+	}
+	return host == strings.TrimPrefix(pattern, "www.")
+}
+
+// matchAnyDomain tells whether the story matches any of the domain patterns
+func matchAnyDomain(story Story, patterns []string) bool {
+	for _, pattern := range patterns {
+		if story.MatchDomain(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowDomains keeps the stories linking to a domain matching any of the
+// patterns, as told by Story.MatchDomain.
+func AllowDomains(patterns []string) Filter {
+	return func(story Story) bool {
+		return matchAnyDomain(story, patterns)
+	}
+}
+
+// DenyDomains leaves out the stories linking to a domain matching any of
+// the patterns, as told by Story.MatchDomain.
+func DenyDomains(patterns []string) Filter {
+	return func(story Story) bool {
+		return !matchAnyDomain(story, patterns)
+	}
+}
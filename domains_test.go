@@ -0,0 +1,48 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDomains(t *testing.T) {
+	patterns, err := readDomains(strings.NewReader("# news sites\nExample.com\n\n  *.blog.org  # and its blogs\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"example.com", "*.blog.org"}, patterns)
+}
+
+func TestLoadDomains(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "domains.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("example.com\n"), 0644))
+	patterns, err := LoadDomains(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"example.com"}, patterns)
+
+	_, err = LoadDomains(filepath.Join(dir, "missing.txt"))
+	assert.NotNil(t, err)
+}
+
+func TestStoryMatchDomain(t *testing.T) {
+	assert.True(t, Story{URL: "https://www.example.com/a"}.MatchDomain("example.com"))
+	assert.False(t, Story{URL: "https://blog.example.com/a"}.MatchDomain("example.com"))
+	assert.True(t, Story{URL: "https://blog.example.com/a"}.MatchDomain("*.example.com"))
+	assert.True(t, Story{URL: "https://example.com/a"}.MatchDomain("*.example.com"))
+	assert.False(t, Story{URL: "https://notexample.com/a"}.MatchDomain("*.example.com"))
+	assert.False(t, Story{URL: "not a url"}.MatchDomain("example.com"))
+}
+
+func TestDomainFilters(t *testing.T) {
+	news := []Story{{URL: "https://example.com"}, {URL: "https://a.blog.org"}, {URL: "https://other.net"}}
+	patterns := []string{"example.com", "*.blog.org"}
+	assert.Equal(t, news[:2], AllowDomains(patterns).Apply(news))
+	assert.Equal(t, news[2:], DenyDomains(patterns).Apply(news))
+}
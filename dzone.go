@@ -0,0 +1,58 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// DZoneURL is the DZone homepage feed
+const DZoneURL = "http://feeds.dzone.com/home"
+
+// dzoneFeedURL is where the feed of every zone lives
+const dzoneFeedURL = "http://feeds.dzone.com/"
+
+// dzoneZones maps the zones to their feed names
+var dzoneZones = map[string]string{
+	"home":          "home",
+	"ai":            "ai",
+	"big-data":      "big-data",
+	"cloud":         "cloud",
+	"database":      "database",
+	"devops":        "devops",
+	"integration":   "integration",
+	"iot":           "iot",
+	"java":          "java",
+	"microservices": "microservices",
+	"open-source":   "opensource",
+	"performance":   "performance",
+	"security":      "security",
+	"web-dev":       "webdev",
+}
+
+func init() {
+	Register(Source{
+		Name:        "dzone",
+		Description: "Latest articles from dzone.com, optionally from one zone",
+		Flags:       []string{"zone"},
+		New: func(opts Options) Fetcher {
+			return &DZoneSource{Client: opts.Client, Zone: opts.Zone}
+		},
+	})
+}
+
+// DZoneSource fetches latest stories from http://feeds.dzone.com/home
+type DZoneSource struct {
+	Client *http.Client
+	// Zone selects a topic portal such as java, devops or cloud instead of
+	// the homepage
+	Zone string
+}
+
+// Fetch gets news from the DZone
+func (l *DZoneSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	feed, err := lookupMode("dzone zone", l.Zone, "home", dzoneZones)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFeed(ctx, l.Client, dzoneFeedURL+feed, "dzone", count)
+}
@@ -0,0 +1,108 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Echo JS URLs
+const (
+	EchoJSURL     = "https://www.echojs.com"
+	EchoJSNewsURL = EchoJSURL + "/news/"
+)
+
+// echoJSModes maps the listing modes to their API names
+var echoJSModes = map[string]string{
+	"latest": "latest",
+	"top":    "top",
+}
+
+func init() {
+	Register(Source{
+		Name:        "echojs",
+		Description: "Latest JavaScript and front-end links from echojs.com",
+		Flags:       []string{"mode"},
+		New: func(opts Options) Fetcher {
+			return &EchoJSSource{Client: opts.Client, Workers: opts.Workers, Mode: opts.Mode}
+		},
+	})
+}
+
+// echoJSNews is a story as returned by the getnews API, which encodes every
+// number as a string
+type echoJSNews struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Up       string `json:"up"`
+	Down     string `json:"down"`
+	Comments string `json:"comments"`
+	Ctime    string `json:"ctime"`
+	Username string `json:"username"`
+}
+
+// EchoJSSource fetches the latest stories from https://www.echojs.com/
+type EchoJSSource struct {
+	Client *http.Client
+	// Workers is the number of pages fetched at once
+	Workers int
+	// Mode selects the listing: latest (default) or top
+	Mode string
+}
+
+// Fetch gets news from Echo JS
+func (l *EchoJSSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	listing, err := lookupMode("echojs mode", l.Mode, "latest", echoJSModes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the API hands out 30 news at a time
+	p := pager{perPage: 30, workers: l.Workers, fetch: func(ctx context.Context, page int) ([]Story, error) {
+		return l.fetchPage(ctx, listing, page)
+	}}
+	return p.collect(ctx, count)
+}
+
+// fetchPage gets a single page of stories from a listing
+func (l *EchoJSSource) fetchPage(ctx context.Context, listing string, page int) ([]Story, error) {
+	var doc struct {
+		Status string       `json:"status"`
+		News   []echoJSNews `json:"news"`
+	}
+	url := fmt.Sprintf("%s/api/getnews/%s/%d/30", EchoJSURL, listing, (page-1)*30)
+	if err := getJSON(ctx, l.Client, url, &doc); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, item := range doc.News {
+		up, _ := strconv.Atoi(item.Up)
+		down, _ := strconv.Atoi(item.Down)
+		comments, _ := strconv.Atoi(item.Comments)
+		ctime, _ := strconv.ParseInt(item.Ctime, 10, 64)
+
+		story := Story{
+			Title:       item.Title,
+			URL:         item.URL,
+			CommentsURL: EchoJSNewsURL + item.ID,
+			Score:       up - down,
+			HasScore:    true,
+			Comments:    comments,
+			Author:      item.Username,
+			Source:      "echojs",
+			PublishedAt: time.Unix(ctime, 0).UTC(),
+		}
+		// text posts only live on Echo JS itself
+		if strings.HasPrefix(story.URL, "text://") {
+			story.URL = story.CommentsURL
+		}
+		news = append(news, story)
+	}
+
+	return news, nil
+}
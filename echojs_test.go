@@ -0,0 +1,37 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const echoJSPage = `{"status": "ok", "news": [
+	{"id": "101", "title": "One", "url": "https://example.com/one", "up": "12", "down": "2", "comments": "3", "ctime": "1538492645", "username": "alice"},
+	{"id": "102", "title": "Ask", "url": "text://Ask", "up": "1", "down": "0", "comments": "0", "ctime": "1538492645", "username": "bob"}
+]}`
+
+func TestEchoJSFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/getnews/top/0/30" {
+			w.Write([]byte(`{"status": "ok", "news": []}`))
+			return
+		}
+		w.Write([]byte(echoJSPage))
+	}))
+	defer done()
+
+	news, err := (&EchoJSSource{Client: client, Mode: "top"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "One", news[0].Title)
+		assert.Equal(t, "https://example.com/one", news[0].URL)
+		assert.Equal(t, EchoJSNewsURL+"101", news[0].CommentsURL)
+		assert.Equal(t, 10, news[0].Score)
+		assert.Equal(t, 3, news[0].Comments)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+		assert.Equal(t, EchoJSNewsURL+"102", news[1].URL)
+	}
+}
@@ -0,0 +1,143 @@
+package hnreader
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(Source{
+		Name:         "rss",
+		Description:  "Any RSS, Atom or JSON feed given with --url",
+		Flags:        []string{"url"},
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &FeedSource{Client: opts.Client, URL: opts.URL}
+		},
+	})
+}
+
+// FeedSource fetches the stories of an arbitrary RSS, Atom or JSON feed
+type FeedSource struct {
+	Client *http.Client
+	// URL of the feed
+	URL string
+}
+
+// Fetch gets the newest entries of the feed
+func (l *FeedSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.URL == "" {
+		return nil, errors.New("the rss source needs a feed URL")
+	}
+	return fetchFeed(ctx, l.Client, l.URL, "rss", count)
+}
+
+// feedDoc decodes RSS 2.0, RSS 1.0 (RDF) and Atom documents alike
+type feedDoc struct {
+	XMLName xml.Name
+	// RSS 2.0 keeps the items inside the channel
+	Items []RssItem `xml:"channel>item"`
+	// RSS 1.0 puts them next to it
+	RDFItems []RssItem `xml:"item"`
+	// Atom
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is a single entry of an Atom feed
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     string         `xml:"author>name"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// atomLink is one of the links of an Atom entry
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// atomCategory is a tag of an Atom entry
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Story converts the Atom entry into a Story from the given source. The
+// alternate link is the article, replies point to the discussion.
+func (entry atomEntry) Story(source string) Story {
+	story := Story{
+		Title:       strings.TrimSpace(entry.Title),
+		Author:      strings.TrimSpace(entry.Author),
+		Source:      source,
+		PublishedAt: parseTime(entry.Published),
+	}
+	if story.PublishedAt.IsZero() {
+		story.PublishedAt = parseTime(entry.Updated)
+	}
+
+	for _, link := range entry.Links {
+		switch link.Rel {
+		case "", "alternate":
+			if story.URL == "" {
+				story.URL = strings.TrimSpace(link.Href)
+			}
+		case "replies":
+			story.CommentsURL = strings.TrimSpace(link.Href)
+		}
+	}
+
+	var categories []string
+	for _, category := range entry.Categories {
+		categories = append(categories, category.Term)
+	}
+	story.Tags = tags(categories)
+	return story
+}
+
+// parseFeed reads the stories of an RSS or Atom document
+func parseFeed(r io.Reader, source string) ([]Story, error) {
+	doc := feedDoc{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, item := range append(doc.Items, doc.RDFItems...) {
+		news = append(news, item.Story(source))
+	}
+	for _, entry := range doc.Entries {
+		news = append(news, entry.Story(source))
+	}
+	return news, nil
+}
+
+// fetchFeed gets up to count stories from the RSS, Atom or JSON feed at url
+func fetchFeed(ctx context.Context, client *http.Client, url, source string, count int) ([]Story, error) {
+	resp, err := get(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body := bufio.NewReader(resp.Body)
+	// a short peek fails at the end of tiny documents, which is fine
+	start, _ := body.Peek(64)
+
+	var news []Story
+	if isJSONFeed(resp.Header.Get("Content-Type"), start) {
+		news, err = parseJSONFeed(body, source)
+	} else {
+		news, err = parseFeed(body, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return truncate(news, count), nil
+}
@@ -0,0 +1,87 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Blog</title>
+<entry><title type="html">Hello</title>
+<link rel="replies" href="https://example.com/hello#comments"/>
+<link href="https://example.com/hello"/>
+<published>2018-10-02T15:04:05Z</published><author><name>Rob</name></author><category term="go"/><category term=" "/></entry>
+<entry><title>Updated only</title><link rel="alternate" href="https://example.com/updated"/><updated>2018-10-03T15:04:05Z</updated></entry>
+</feed>`
+
+const rdfFeed = `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel><title>Old school</title></channel>
+<item><title>RDF item</title><link>https://example.com/rdf</link><dc:creator>Ken</dc:creator><category>unix</category><category>history</category><dc:date>2018-10-02T15:04:05Z</dc:date></item>
+</rdf:RDF>`
+
+func TestParseFeedAtom(t *testing.T) {
+	news, err := parseFeed(strings.NewReader(atomFeed), "rss")
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "Hello", news[0].Title)
+		assert.Equal(t, "https://example.com/hello", news[0].URL)
+		assert.Equal(t, "https://example.com/hello#comments", news[0].CommentsURL)
+		assert.Equal(t, 2, news[0].PublishedAt.Day())
+		assert.Equal(t, "Rob", news[0].Author)
+		assert.Equal(t, []string{"go"}, news[0].Tags)
+		assert.Equal(t, "https://example.com/updated", news[1].URL)
+		assert.Equal(t, 3, news[1].PublishedAt.Day())
+	}
+}
+
+func TestParseFeedRDF(t *testing.T) {
+	news, err := parseFeed(strings.NewReader(rdfFeed), "rss")
+	assert.Nil(t, err)
+	if assert.Len(t, news, 1) {
+		assert.Equal(t, "https://example.com/rdf", news[0].URL)
+		assert.Equal(t, "Ken", news[0].Author)
+		assert.Equal(t, []string{"unix", "history"}, news[0].Tags)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+	}
+}
+
+func TestFeedSourceFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/feed.atom", r.URL.Path)
+		w.Write([]byte(atomFeed))
+	}))
+	defer done()
+
+	news, err := (&FeedSource{Client: client, URL: "https://example.com/feed.atom"}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "rss", news[0].Source)
+
+	_, err = (&FeedSource{Client: client}).Fetch(context.Background(), 1)
+	assert.NotNil(t, err)
+}
+
+func TestPinboardFetch(t *testing.T) {
+	var paths []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(rdfFeed))
+	}))
+	defer done()
+
+	news, err := (&PinboardSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "pinboard", news[0].Source)
+
+	_, err = (&PinboardSource{Client: client, Mode: "recent"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	_, err = (&PinboardSource{Client: client, Tags: []string{"golang"}}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/rss/popular/", "/rss/recent/", "/rss/t:golang/"}, paths)
+}
@@ -0,0 +1,175 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Fetcher retrieves stories from a source.
+type Fetcher interface {
+	// Fetch returns up to count stories. When ctx is cancelled part way
+	// through, the stories retrieved so far are returned with the error.
+	Fetch(ctx context.Context, count int) ([]Story, error)
+}
+
+// Options configures the fetchers created by NewFetcher.
+type Options struct {
+	// Client is used for every HTTP request. http.DefaultClient is used
+	// when it is nil.
+	Client *http.Client
+	// Workers limits how many pages paginated sources fetch at once.
+	// DefaultWorkers is used when it is zero.
+	Workers int
+	// Mode picks one of the listings of sources that have several, such
+	// as "best" or "ask" for Hacker News. Empty means the default one.
+	Mode string
+	// Subreddits read by the reddit source.
+	Subreddits []string
+	// Sort orders the reddit listing: hot, new, top or rising.
+	Sort string
+	// Time is the window of reddit's top listing, such as "week".
+	Time string
+	// Multi is a reddit multireddit such as "user/m/tech".
+	Multi string
+	// NSFW keeps the reddit posts marked over 18, which are skipped
+	// otherwise.
+	NSFW bool
+	// LinksOnly skips reddit's text posts, keeping those linking away.
+	LinksOnly bool
+	// URL is the address read by sources that take one, such as a feed.
+	URL string
+	// Path is the local file read by the file source.
+	Path string
+	// OPML is the path of an OPML file listing feeds to read.
+	OPML string
+	// Topic is the section or topic read by sources that have them, such
+	// as "gadgets" for Ars Technica.
+	Topic string
+	// Language restricts GitHub trending to one programming language.
+	Language string
+	// Since is the time range of GitHub trending: daily, weekly or monthly.
+	Since string
+	// Repos are the GitHub repositories, like "golang/go", whose releases
+	// are read.
+	Repos []string
+	// Groups are the Tildes groups read, such as "comp".
+	Groups []string
+	// Instance is the Mastodon server asked for trending links.
+	Instance string
+	// Categories are read by sources that have them, such as "cs.LG"
+	// for arXiv.
+	Categories []string
+	// Ecosystems restricts security advisories to packages of these
+	// ecosystems, such as "go" or "npm".
+	Ecosystems []string
+	// Severity is the lowest severity of the advisories kept.
+	Severity string
+	// Locale is the language and region of sources that have editions,
+	// such as "en-US" for Google News.
+	Locale string
+	// Query is the keyword search of sources that support one.
+	Query string
+	// NewsAPIKey is the NewsAPI.org API key.
+	NewsAPIKey string
+	// Zone is the DZone portal to read, such as "java".
+	Zone string
+	// Tags restricts sources that support it to stories with these tags.
+	Tags []string
+	// RedditAuth logs the reddit source in when set.
+	RedditAuth *RedditAuth
+}
+
+// SourceFactory creates a Fetcher for a registered source.
+type SourceFactory func(opts Options) Fetcher
+
+// Source describes a registered news source.
+type Source struct {
+	Name        string
+	Description string
+	// Flags are the command line flags the source understands, without
+	// their dashes, such as "mode".
+	Flags []string
+	// Credentials tells what the source needs from the configuration,
+	// such as "newsapi key (required)". Empty means nothing.
+	Credentials string
+	// NeedsOptions marks the sources that can't run without some of their
+	// flags, credentials or input, which are never picked at random.
+	NeedsOptions bool
+	New          SourceFactory
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// Register makes src available under its name. Sources normally register
+// themselves from an init function. Registering a name twice panics.
+func Register(src Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if src.New == nil {
+		panic("hnreader: Register factory is nil for source " + src.Name)
+	}
+	if _, dup := sources[src.Name]; dup {
+		panic("hnreader: Register called twice for source " + src.Name)
+	}
+	sources[src.Name] = src
+}
+
+// Sources returns every registered source sorted by name.
+func Sources() []Source {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	list := make([]Source, 0, len(sources))
+	for _, src := range sources {
+		list = append(list, src)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// SourceNames returns the names of every registered source in sorted order.
+func SourceNames() []string {
+	var names []string
+	for _, src := range Sources() {
+		names = append(names, src.Name)
+	}
+	return names
+}
+
+// NewFetcher creates a Fetcher for the source registered under name.
+func NewFetcher(name string, opts Options) (Fetcher, error) {
+	sourcesMu.RLock()
+	src, ok := sources[name]
+	sourcesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q (available: %s)", name, strings.Join(SourceNames(), ", "))
+	}
+	return src.New(opts), nil
+}
+
+// lookupMode returns the value of mode in modes, using fallback when mode is
+// empty. Unknown modes are reported as what, together with the valid ones.
+func lookupMode(what, mode, fallback string, modes map[string]string) (string, error) {
+	if mode == "" {
+		mode = fallback
+	}
+	if value, ok := modes[mode]; ok {
+		return value, nil
+	}
+
+	var valid []string
+	for name := range modes {
+		valid = append(valid, name)
+	}
+	sort.Strings(valid)
+	return "", fmt.Errorf("unknown %s %q (one of %s)", what, mode, strings.Join(valid, ", "))
+}
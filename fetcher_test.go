@@ -0,0 +1,63 @@
+package hnreader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource struct{}
+
+func (f *fakeSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return []Story{{Title: "fake", URL: "https://example.com", Source: "fake"}}, nil
+}
+
+func TestRegister(t *testing.T) {
+	Register(Source{Name: "test-fake", Description: "A fake source", Flags: []string{"mode"}, New: func(Options) Fetcher { return new(fakeSource) }})
+	defer func() {
+		sourcesMu.Lock()
+		delete(sources, "test-fake")
+		sourcesMu.Unlock()
+	}()
+
+	assert.Contains(t, SourceNames(), "test-fake")
+	for _, src := range Sources() {
+		if src.Name == "test-fake" {
+			assert.Equal(t, []string{"mode"}, src.Flags)
+		}
+	}
+
+	src, err := NewFetcher("test-fake", Options{})
+	assert.Nil(t, err)
+	assert.IsType(t, new(fakeSource), src)
+
+	assert.Panics(t, func() {
+		Register(Source{Name: "test-fake", Description: "Again", New: func(Options) Fetcher { return new(fakeSource) }})
+	})
+}
+
+func TestBuiltinSourcesRegistered(t *testing.T) {
+	assert.Subset(t, SourceNames(), []string{"advisories", "ars", "arxiv", "auto", "changelog", "devto", "dzone", "echojs", "file", "freecodecamp", "github-releases", "github-trending", "gnews", "golangweekly", "hackernoon", "hn", "hn-api", "indiehackers", "infoq", "lobsters", "mastodon", "newsapi", "opml", "phoronix", "pinboard", "reddit", "register", "rss", "sidebar", "stackoverflow", "stdin", "tildes", "twir"})
+}
+
+func TestNewFetcherUnknown(t *testing.T) {
+	src, err := NewFetcher("nope", Options{})
+	assert.Nil(t, src)
+	assert.NotNil(t, err)
+}
+
+func TestLookupMode(t *testing.T) {
+	modes := map[string]string{"top": "news", "best": "best"}
+
+	value, err := lookupMode("hn mode", "", "top", modes)
+	assert.Nil(t, err)
+	assert.Equal(t, "news", value)
+
+	value, err = lookupMode("hn mode", "best", "top", modes)
+	assert.Nil(t, err)
+	assert.Equal(t, "best", value)
+
+	_, err = lookupMode("hn mode", "worst", "top", modes)
+	assert.EqualError(t, err, `unknown hn mode "worst" (one of best, top)`)
+}
@@ -0,0 +1,73 @@
+package hnreader
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+func init() {
+	Register(Source{
+		Name:         "file",
+		Description:  "URLs listed one per line in the file given with --path",
+		Flags:        []string{"path"},
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &FileSource{Path: opts.Path}
+		},
+	})
+}
+
+// FileSource reads the URLs of a local file, one per line. Blank lines and
+// lines starting with # are skipped, and a comment after a URL becomes the
+// title of its story.
+type FileSource struct {
+	Path string
+}
+
+// Fetch reads up to count URLs from the file
+func (l *FileSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.Path == "" {
+		return nil, errors.New("the file source needs a file")
+	}
+
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readURLs(f, "file", count)
+}
+
+// readURLs reads up to count stories from a list of URLs, one per line,
+// skipping the ones listed twice
+func readURLs(r io.Reader, source string, count int) ([]Story, error) {
+	seen := make(map[string]bool)
+	var news []Story
+
+	scanner := bufio.NewScanner(r)
+	for len(news) < count && scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// fragments are part of URLs, comments need a space before them
+		url, title := line, line
+		if i := strings.Index(line, " #"); i >= 0 {
+			url = strings.TrimSpace(line[:i])
+			title = strings.TrimSpace(line[i+2:])
+		}
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		news = append(news, Story{Title: title, URL: url, Source: source})
+	}
+
+	return news, scanner.Err()
+}
@@ -0,0 +1,59 @@
+package hnreader
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const urlList = `# reading list
+https://example.com/one
+
+https://example.com/two#section   # The second one
+  https://example.com/three
+https://example.com/one
+`
+
+func TestReadURLs(t *testing.T) {
+	news, err := readURLs(strings.NewReader(urlList), "file", 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "https://example.com/one", news[0].URL)
+		assert.Equal(t, "https://example.com/one", news[0].Title)
+		assert.Equal(t, "https://example.com/two#section", news[1].URL)
+		assert.Equal(t, "The second one", news[1].Title)
+		assert.Equal(t, "https://example.com/three", news[2].URL)
+	}
+
+	news, err = readURLs(strings.NewReader(urlList), "file", 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+}
+
+func TestFileSourceFetch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "urls.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(urlList), 0644))
+
+	news, err := (&FileSource{Path: path}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "file", news[0].Source)
+
+	_, err = (&FileSource{Path: filepath.Join(dir, "missing.txt")}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+}
+
+func TestStdinSourceFetch(t *testing.T) {
+	news, err := (&StdinSource{Reader: strings.NewReader(urlList)}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "stdin", news[0].Source)
+}
@@ -0,0 +1,140 @@
+package hnreader
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Filter tells whether to keep a story.
+type Filter func(Story) bool
+
+// Apply returns the stories f keeps, in their order.
+func (f Filter) Apply(news []Story) []Story {
+	var kept []Story
+	for _, story := range news {
+		if f(story) {
+			kept = append(kept, story)
+		}
+	}
+	return kept
+}
+
+// AllFilters keeps the stories every one of filters keeps.
+func AllFilters(filters ...Filter) Filter {
+	return func(story Story) bool {
+		for _, f := range filters {
+			if !f(story) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ByAuthors keeps the stories submitted or written by any of the authors,
+// ignoring case.
+func ByAuthors(authors []string) Filter {
+	return func(story Story) bool {
+		for _, author := range authors {
+			if strings.EqualFold(story.Author, author) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Tagged keeps the stories with any of the tags, ignoring case.
+func Tagged(tags []string) Filter {
+	return func(story Story) bool {
+		for _, tag := range story.Tags {
+			for _, wanted := range tags {
+				if strings.EqualFold(tag, wanted) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// Matching keeps the stories whose title or URL contains any of the
+// keywords, ignoring case.
+func Matching(keywords []string) Filter {
+	return func(story Story) bool {
+		return containsAny(story, keywords)
+	}
+}
+
+// Excluding leaves out the stories whose title or URL contains any of the
+// keywords, ignoring case, and those linking to any of the domains or their
+// subdomains.
+func Excluding(keywords, domains []string) Filter {
+	return func(story Story) bool {
+		for _, domain := range domains {
+			if story.OnDomain(domain) {
+				return false
+			}
+		}
+		return !containsAny(story, keywords)
+	}
+}
+
+// MatchingRegexp keeps the stories whose title or URL matches re.
+func MatchingRegexp(re *regexp.Regexp) Filter {
+	return func(story Story) bool {
+		return re.MatchString(story.Title) || re.MatchString(story.URL)
+	}
+}
+
+// ExcludingRegexp leaves out the stories whose title or URL matches re.
+func ExcludingRegexp(re *regexp.Regexp) Filter {
+	return func(story Story) bool {
+		return !re.MatchString(story.Title) && !re.MatchString(story.URL)
+	}
+}
+
+// containsAny tells whether the title or URL of the story contains any of
+// the keywords, ignoring case
+func containsAny(story Story, keywords []string) bool {
+	title, url := strings.ToLower(story.Title), strings.ToLower(story.URL)
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(keyword)
+		if strings.Contains(title, keyword) || strings.Contains(url, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// MinScore keeps the stories with at least min points. Stories without a
+// score, from sources that don't rank them, are kept too.
+func MinScore(min int) Filter {
+	return func(story Story) bool {
+		return !story.HasScore || story.Score >= min
+	}
+}
+
+// MaxAge keeps the stories published less than age before now. Stories
+// without a publication time are kept too.
+func MaxAge(age time.Duration, now time.Time) Filter {
+	return func(story Story) bool {
+		return story.PublishedAt.IsZero() || now.Sub(story.PublishedAt) < age
+	}
+}
+
+// MaxPerDomain keeps the first max stories linking to each domain, and
+// those whose domain can't be told. It counts the stories it is given, so
+// each list of stories needs a filter of its own.
+func MaxPerDomain(max int) Filter {
+	counts := make(map[string]int)
+	return func(story Story) bool {
+		domain := story.Domain()
+		if domain == "" {
+			return true
+		}
+		counts[domain]++
+		return counts[domain] <= max
+	}
+}
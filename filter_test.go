@@ -0,0 +1,94 @@
+package hnreader
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	news := []Story{
+		{Title: "Popular", Score: 300, HasScore: true},
+		{Title: "Unscored"},
+		{Title: "Quiet", Score: 4, HasScore: true},
+		{Title: "Ask HN", Score: 120, HasScore: true},
+		{Title: "Flagged", HasScore: true},
+	}
+
+	assert.Equal(t, []Story{news[0], news[1], news[3]}, MinScore(100).Apply(news))
+
+	notAsk := Filter(func(story Story) bool { return story.Title != "Ask HN" })
+	assert.Equal(t, []Story{news[0], news[1]}, AllFilters(MinScore(100), notAsk).Apply(news))
+	assert.Equal(t, news, AllFilters().Apply(news))
+	assert.Nil(t, MinScore(1000).Apply(news[:1]))
+}
+
+func TestTagged(t *testing.T) {
+	news := []Story{{Title: "One", Tags: []string{"go", "release"}}, {Title: "Two", Tags: []string{"rust"}}, {Title: "Three"}}
+	assert.Equal(t, news[:1], Tagged([]string{"Go"}).Apply(news))
+	assert.Equal(t, news[:2], Tagged([]string{"rust", "go"}).Apply(news))
+}
+
+func TestByAuthors(t *testing.T) {
+	news := []Story{{Title: "One", Author: "pg"}, {Title: "Two", Author: "dang"}, {Title: "Three"}}
+	assert.Equal(t, news[:2], ByAuthors([]string{"PG", "dang"}).Apply(news))
+	assert.Nil(t, ByAuthors(nil).Apply(news))
+}
+
+func TestMatching(t *testing.T) {
+	news := []Story{
+		{Title: "Running Kubernetes at home", URL: "https://example.com/k8s"},
+		{Title: "A new runtime", URL: "https://example.com/wasm-runtime"},
+		{Title: "Something else", URL: "https://example.com"},
+	}
+	assert.Equal(t, news[:2], Matching([]string{"kubernetes", "WASM"}).Apply(news))
+	assert.Nil(t, Matching(nil).Apply(news))
+}
+
+func TestExcluding(t *testing.T) {
+	news := []Story{
+		{Title: "Crypto is back", URL: "https://example.com/1"},
+		{Title: "A blog post", URL: "https://blog.muted.org/post"},
+		{Title: "Something else", URL: "https://example.com/nft-gallery"},
+		{Title: "Kept", URL: "https://notmuted.org"},
+	}
+	assert.Equal(t, news[3:], Excluding([]string{"CRYPTO", "nft"}, []string{"muted.org"}).Apply(news))
+	assert.Equal(t, news, Excluding(nil, nil).Apply(news))
+}
+
+func TestRegexpFilters(t *testing.T) {
+	news := []Story{
+		{Title: "Go 1.23 is released", URL: "https://go.dev/blog"},
+		{Title: "Ask HN: Go or Rust?", URL: "https://news.ycombinator.com/item?id=1"},
+		{Title: "Gopher plush", URL: "https://example.com"},
+	}
+	re := regexp.MustCompile(`(?i)\bgo\b`)
+	assert.Equal(t, news[:2], MatchingRegexp(re).Apply(news))
+	assert.Equal(t, []Story{news[0], news[2]}, ExcludingRegexp(regexp.MustCompile(`^Ask HN`)).Apply(news))
+	assert.Equal(t, news[2:], ExcludingRegexp(regexp.MustCompile(`ycombinator|\.dev/`)).Apply(news))
+}
+
+func TestMaxAge(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	news := []Story{
+		{Title: "Fresh", PublishedAt: now.Add(-time.Hour)},
+		{Title: "Undated"},
+		{Title: "Evergreen", PublishedAt: now.AddDate(-3, 0, 0)},
+	}
+	assert.Equal(t, news[:2], MaxAge(24*time.Hour, now).Apply(news))
+}
+
+func TestMaxPerDomain(t *testing.T) {
+	news := []Story{
+		{URL: "https://example.com/1"},
+		{URL: "https://www.example.com/2"},
+		{URL: "https://other.org/1"},
+		{URL: "https://example.com/3"},
+		{URL: ""},
+		{URL: ""},
+	}
+	assert.Equal(t, []Story{news[0], news[1], news[2], news[4], news[5]}, MaxPerDomain(2).Apply(news))
+	assert.Equal(t, []Story{news[0], news[2], news[4], news[5]}, MaxPerDomain(1).Apply(news))
+}
@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// FreeCodeCampURL is the freeCodeCamp News feed
+const FreeCodeCampURL = "https://www.freecodecamp.org/news/rss/"
+
+func init() {
+	Register(Source{
+		Name:        "freecodecamp",
+		Description: "Tutorials and articles from freeCodeCamp News",
+		New: func(opts Options) Fetcher {
+			return &FreeCodeCampSource{Client: opts.Client}
+		},
+	})
+}
+
+// FreeCodeCampSource fetches latest stories from https://www.freecodecamp.org/news/
+type FreeCodeCampSource struct {
+	Client *http.Client
+}
+
+// Fetch gets news from freeCodeCamp
+func (l *FreeCodeCampSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return fetchFeed(ctx, l.Client, FreeCodeCampURL, "freecodecamp", count)
+}
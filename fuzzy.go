@@ -0,0 +1,48 @@
+package hnreader
+
+import (
+	"strings"
+)
+
+// FuzzyMatch tells whether the story matches query the way fzf does: every
+// word of the query must appear in the title or the domain of the story,
+// its letters in order but not necessarily next to each other.
+func FuzzyMatch(query string, story Story) bool {
+	text := strings.ToLower(story.Title + " " + story.Domain())
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !subsequence(word, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// FuzzyFilter returns the stories matching query, all of them when it is
+// empty
+func FuzzyFilter(query string, news []Story) []Story {
+	if strings.TrimSpace(query) == "" {
+		return news
+	}
+	var matches []Story
+	for _, story := range news {
+		if FuzzyMatch(query, story) {
+			matches = append(matches, story)
+		}
+	}
+	return matches
+}
+
+// subsequence tells whether the runes of word appear in text in order
+func subsequence(word, text string) bool {
+	letters := []rune(word)
+	i := 0
+	for _, r := range text {
+		if i == len(letters) {
+			break
+		}
+		if r == letters[i] {
+			i++
+		}
+	}
+	return i == len(letters)
+}
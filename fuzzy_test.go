@@ -0,0 +1,24 @@
+package hnreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	story := Story{Title: "Asynchronous Rust in practice", URL: "https://www.example.com/async-rust"}
+	assert.True(t, FuzzyMatch("rust async", story))
+	assert.True(t, FuzzyMatch("RST asnc", story))
+	assert.True(t, FuzzyMatch("example.com", story))
+	assert.True(t, FuzzyMatch("", story))
+	assert.False(t, FuzzyMatch("rust tokio", story))
+	// the path of the URL isn't searched
+	assert.False(t, FuzzyMatch("async-rust", story))
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	news := []Story{{Title: "Go 1.11 released"}, {Title: "Rust 2018"}, {Title: "Going further"}}
+	assert.Equal(t, news, FuzzyFilter(" ", news))
+	assert.Equal(t, []Story{news[0], news[2]}, FuzzyFilter("go", news))
+}
@@ -0,0 +1,50 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+func init() {
+	Register(Source{
+		Name:         "github-releases",
+		Description:  "New releases of the GitHub repositories listed in the configuration",
+		Credentials:  "github repos (required)",
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &GitHubReleasesSource{Client: opts.Client, Workers: opts.Workers, Repos: opts.Repos}
+		},
+	})
+}
+
+// GitHubReleasesSource reads the releases feed of a list of repositories
+type GitHubReleasesSource struct {
+	Client *http.Client
+	// Workers is the number of feeds fetched at once
+	Workers int
+	// Repos such as "golang/go"
+	Repos []string
+}
+
+// Fetch gets the newest releases across all repositories
+func (l *GitHubReleasesSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if len(l.Repos) == 0 {
+		return nil, errors.New("the github-releases source needs repositories in the configuration")
+	}
+
+	news, err := fetchPages(ctx, 0, len(l.Repos)-1, l.Workers, func(ctx context.Context, i int) ([]Story, error) {
+		repo := l.Repos[i]
+		releases, err := fetchFeed(ctx, l.Client, GitHubURL+"/"+repo+"/releases.atom", "github-releases", count)
+		for i := range releases {
+			releases[i].Title = repo + " " + releases[i].Title
+		}
+		return releases, err
+	})
+
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+	return truncate(news, count), err
+}
@@ -0,0 +1,36 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubReleasesFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo := strings.TrimSuffix(r.URL.Path, "/releases.atom")
+		day := "01"
+		if repo == "/golang/go" {
+			day = "02"
+		}
+		w.Write([]byte(`<feed xmlns="http://www.w3.org/2005/Atom">
+<entry><title>v1.0</title><link rel="alternate" href="https://github.com` + repo + `/releases/tag/v1.0"/><updated>2018-10-` + day + `T15:04:05Z</updated></entry>
+</feed>`))
+	}))
+	defer done()
+
+	src := &GitHubReleasesSource{Client: client, Repos: []string{"kubernetes/kubernetes", "golang/go"}}
+	news, err := src.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "golang/go v1.0", news[0].Title)
+		assert.Equal(t, "https://github.com/golang/go/releases/tag/v1.0", news[0].URL)
+		assert.Equal(t, "kubernetes/kubernetes v1.0", news[1].Title)
+	}
+
+	_, err = (&GitHubReleasesSource{Client: client}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+}
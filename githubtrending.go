@@ -0,0 +1,92 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// GitHubURL is the GitHub homepage
+const GitHubURL = "https://github.com"
+
+// gitHubTrendingPeriods are the time ranges of the trending page
+var gitHubTrendingPeriods = map[string]string{
+	"daily":   "daily",
+	"weekly":  "weekly",
+	"monthly": "monthly",
+}
+
+func init() {
+	Register(Source{
+		Name:        "github-trending",
+		Description: "Trending repositories on github.com",
+		Flags:       []string{"lang", "since"},
+		New: func(opts Options) Fetcher {
+			return &GitHubTrendingSource{Client: opts.Client, Language: opts.Language, Since: opts.Since}
+		},
+	})
+}
+
+// GitHubTrendingSource scrapes the trending repositories of
+// https://github.com/trending
+type GitHubTrendingSource struct {
+	Client *http.Client
+	// Language restricts the repositories to one language, like "go"
+	Language string
+	// Since is the time range: daily (default), weekly or monthly
+	Since string
+}
+
+// Fetch gets the trending repositories. GitHub lists at most 25 of them.
+func (l *GitHubTrendingSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	since, err := lookupMode("github-trending range", l.Since, "daily", gitHubTrendingPeriods)
+	if err != nil {
+		return nil, err
+	}
+
+	page := GitHubURL + "/trending"
+	if l.Language != "" {
+		page += "/" + url.PathEscape(strings.ToLower(l.Language))
+	}
+
+	resp, err := get(ctx, l.Client, page+"?since="+since)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find("article.Box-row").Each(func(_ int, s *goquery.Selection) {
+		href, exist := s.Find("h1 a, h2 a").First().Attr("href")
+		if !exist {
+			return
+		}
+
+		repo := strings.Trim(href, "/")
+		title := repo
+		if description := strings.TrimSpace(s.Find("p").First().Text()); description != "" {
+			title = fmt.Sprintf("%s: %s", repo, description)
+		}
+
+		stars := s.Find(`a[href$="/stargazers"]`).First().Text()
+		news = append(news, Story{
+			Title:    title,
+			URL:      GitHubURL + "/" + repo,
+			Score:    leadingInt(strings.Replace(stars, ",", "", -1)),
+			HasScore: true,
+			Source:   "github-trending",
+		})
+	})
+
+	return truncate(news, count), nil
+}
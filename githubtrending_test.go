@@ -0,0 +1,41 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const gitHubTrendingPage = `<html><body>
+<article class="Box-row">
+	<h2 class="h3 lh-condensed"><a href="/golang/go">golang / go</a></h2>
+	<p class="col-9">The Go programming language</p>
+	<a class="Link--muted" href="/golang/go/stargazers"> 12,345 </a>
+</article>
+<article class="Box-row">
+	<h2 class="h3 lh-condensed"><a href="/someone/tool">someone / tool</a></h2>
+</article>
+</body></html>`
+
+func TestGitHubTrendingFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/trending/go", r.URL.Path)
+		assert.Equal(t, "weekly", r.URL.Query().Get("since"))
+		w.Write([]byte(gitHubTrendingPage))
+	}))
+	defer done()
+
+	news, err := (&GitHubTrendingSource{Client: client, Language: "Go", Since: "weekly"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "golang/go: The Go programming language", news[0].Title)
+		assert.Equal(t, "https://github.com/golang/go", news[0].URL)
+		assert.Equal(t, 12345, news[0].Score)
+		assert.Equal(t, "someone/tool", news[1].Title)
+	}
+
+	_, err = (&GitHubTrendingSource{Client: client, Since: "yearly"}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+}
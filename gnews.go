@@ -0,0 +1,76 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GoogleNewsURL is where the Google News topic feeds live
+const GoogleNewsURL = "https://news.google.com/rss/headlines/section/topic/"
+
+// DefaultGoogleNewsLocale is used when no locale is given
+const DefaultGoogleNewsLocale = "en-US"
+
+// googleNewsTopics maps the topics to their feed names
+var googleNewsTopics = map[string]string{
+	"business":      "BUSINESS",
+	"entertainment": "ENTERTAINMENT",
+	"health":        "HEALTH",
+	"nation":        "NATION",
+	"science":       "SCIENCE",
+	"sports":        "SPORTS",
+	"technology":    "TECHNOLOGY",
+	"world":         "WORLD",
+}
+
+func init() {
+	Register(Source{
+		Name:        "gnews",
+		Description: "Google News headlines on a topic",
+		Flags:       []string{"topic", "hl"},
+		New: func(opts Options) Fetcher {
+			return &GoogleNewsSource{Client: opts.Client, Topic: opts.Topic, Locale: opts.Locale}
+		},
+	})
+}
+
+// GoogleNewsSource fetches the headlines of a https://news.google.com/ topic
+type GoogleNewsSource struct {
+	Client *http.Client
+	// Topic such as science or business, technology by default
+	Topic string
+	// Locale is the language and region of the edition, like en-US or
+	// fr-CA, DefaultGoogleNewsLocale when empty
+	Locale string
+}
+
+// Fetch gets the headlines of the topic
+func (l *GoogleNewsSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	topic, err := lookupMode("gnews topic", l.Topic, "technology", googleNewsTopics)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchFeed(ctx, l.Client, GoogleNewsURL+topic+"?"+googleNewsEdition(l.Locale).Encode(), "gnews", count)
+}
+
+// googleNewsEdition returns the query selecting the edition of a locale. An
+// edition is a language together with a country, which defaults to the US.
+func googleNewsEdition(locale string) url.Values {
+	if locale == "" {
+		locale = DefaultGoogleNewsLocale
+	}
+	parts := strings.SplitN(locale, "-", 2)
+	lang, country := parts[0], "US"
+	if len(parts) == 2 {
+		country = strings.ToUpper(parts[1])
+	}
+
+	v := url.Values{}
+	v.Set("hl", locale)
+	v.Set("gl", country)
+	v.Set("ceid", country+":"+lang)
+	return v
+}
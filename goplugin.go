@@ -0,0 +1,103 @@
+package hnreader
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"plugin"
+	"strings"
+)
+
+// PluginDir returns the directory Go plugins are loaded from, plugins inside
+// ConfigDir.
+func PluginDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
+
+// LoadGoPlugins registers the Go plugins (.so files built with
+// -buildmode=plugin) found in dir. The source is named after the file and
+// created by the NewFetcher function the plugin exports, which has either of
+// these signatures:
+//
+//	func NewFetcher() hnreader.Fetcher
+//	func NewFetcher(opts hnreader.Options) hnreader.Fetcher
+//
+// The plugin can also export a Source variable giving the description,
+// flags and credentials of the source. Its Name and New are ignored.
+//
+// Go only supports plugins on Linux and macOS. A missing dir is not an error,
+// plugins that can't be loaded are skipped and reported together.
+func LoadGoPlugins(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".so" {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		if err := loadGoPlugin(strings.TrimSuffix(file.Name(), ".so"), path); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// loadGoPlugin opens a single plugin and registers it as name
+func loadGoPlugin(name, path string) error {
+	if isRegistered(name) {
+		return fmt.Errorf("source %q is already defined", name)
+	}
+
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+	symbol, err := p.Lookup("NewFetcher")
+	if err != nil {
+		return err
+	}
+
+	var factory SourceFactory
+	switch newFetcher := symbol.(type) {
+	case func() Fetcher:
+		factory = func(Options) Fetcher { return newFetcher() }
+	case func(Options) Fetcher:
+		factory = newFetcher
+	default:
+		return fmt.Errorf("NewFetcher has the unsupported type %T", symbol)
+	}
+
+	var src Source
+	if symbol, err := p.Lookup("Source"); err == nil {
+		declared, ok := symbol.(*Source)
+		if !ok {
+			return fmt.Errorf("Source has the unsupported type %T", symbol)
+		}
+		src = *declared
+	}
+	src.Name = name
+	src.New = factory
+	if src.Description == "" {
+		src.Description = "Go plugin " + path
+	}
+	Register(src)
+	return nil
+}
@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadGoPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, LoadGoPlugins(filepath.Join(dir, "missing")))
+
+	// other files are ignored, broken plugins reported
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("hi"), 0644))
+	assert.Nil(t, LoadGoPlugins(dir))
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "test-broken.so"), []byte("not a plugin"), 0644))
+	err = LoadGoPlugins(dir)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "test-broken.so")
+	}
+	assert.NotContains(t, SourceNames(), "test-broken")
+}
@@ -0,0 +1,109 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Hacker News URLs
+const (
+	HackerNewsURL     = "https://news.ycombinator.com"
+	HackerNewsItemURL = HackerNewsURL + "/item?id="
+)
+
+// hackerNewsModes maps the listing modes to their pages
+var hackerNewsModes = map[string]string{
+	"top":    "news",
+	"best":   "best",
+	"newest": "newest",
+	"ask":    "ask",
+	"show":   "show",
+	"jobs":   "jobs",
+}
+
+func init() {
+	Register(Source{
+		Name:        "hn",
+		Description: "Hacker News front page",
+		Flags:       []string{"mode"},
+		New: func(opts Options) Fetcher {
+			return &HackerNewsSource{Client: opts.Client, Workers: opts.Workers, Mode: opts.Mode}
+		},
+	})
+}
+
+// HackerNewsSource fetches new stories from news.ycombinator.com.
+type HackerNewsSource struct {
+	Client *http.Client
+	// Workers is the number of pages fetched at once
+	Workers int
+	// Mode selects the listing: top (default), best, newest, ask, show or jobs
+	Mode string
+}
+
+// Fetch gets news from the HackerNews
+func (hn *HackerNewsSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	listing, err := lookupMode("hn mode", hn.Mode, "top", hackerNewsModes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 30 news per page
+	p := pager{perPage: 30, workers: hn.Workers, fetch: func(ctx context.Context, page int) ([]Story, error) {
+		return hn.fetchPage(ctx, listing, page)
+	}}
+	return p.collect(ctx, count)
+}
+
+// fetchPage scrapes a single page of stories from a listing
+func (hn *HackerNewsSource) fetchPage(ctx context.Context, listing string, page int) ([]Story, error) {
+	resp, err := get(ctx, hn.Client, fmt.Sprintf("%s/%s?p=%d", HackerNewsURL, listing, page))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find("tr.athing").Each(func(_ int, s *goquery.Selection) {
+		// a.storylink is the markup of before 2022
+		link := s.Find(".titleline > a, a.storylink").First()
+		href, exist := link.Attr("href")
+		if !exist {
+			return
+		}
+
+		// Ask HN, Show HN and job posts link to their own item page
+		if !strings.HasPrefix(href, "http") {
+			href = HackerNewsURL + "/" + href
+		}
+
+		id, _ := s.Attr("id")
+		// points live in the row following the title
+		subtext := s.Next()
+		published, _ := subtext.Find("span.age").Attr("title")
+
+		news = append(news, Story{
+			Title:       link.Text(),
+			URL:         href,
+			CommentsURL: HackerNewsItemURL + id,
+			Score:       leadingInt(subtext.Find("span.score").Text()),
+			HasScore:    subtext.Find("span.score").Length() > 0,
+			Comments:    leadingInt(subtext.Find("a").Last().Text()),
+			Author:      subtext.Find("a.hnuser").Text(),
+			Source:      "hn",
+			PublishedAt: parseTime(strings.SplitN(published, " ", 2)[0]),
+		})
+	})
+
+	return news, nil
+}
@@ -0,0 +1,90 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const hackerNewsPage = `<html><body><table>
+<tr class="athing" id="101"><td class="title"><span class="titleline"><a href="https://example.com/one">One</a><span class="sitebit comhead"> (<a href="from?site=example.com"><span class="sitestr">example.com</span></a>)</span></span></td></tr>
+<tr><td class="subtext"><span class="score">42 points</span> by <a href="user?id=pg" class="hnuser">pg</a> <span class="age" title="2018-10-02T15:04:05 1538492645">1 hour ago</span> | <a href="item?id=101">5&nbsp;comments</a></td></tr>
+<tr class="athing" id="102"><td class="title"><span class="titleline"><a href="item?id=102">Ask HN: Two</a></span></td></tr>
+<tr><td class="subtext"><span class="age" title="2018-10-02T14:04:05">2 hours ago</span></td></tr>
+<tr class="athing" id="103"><td class="title"><span class="titleline">[flagged]</span></td></tr>
+<tr><td class="subtext"></td></tr>
+</table></body></html>`
+
+func TestHackerNewsFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(hackerNewsPage))
+	}))
+	defer done()
+
+	// the row without a link is skipped
+	news, err := (&HackerNewsSource{Client: client}).Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	assert.Equal(t, "One", news[0].Title)
+	assert.Equal(t, "https://example.com/one", news[0].URL)
+	assert.Equal(t, HackerNewsItemURL+"101", news[0].CommentsURL)
+	assert.Equal(t, 42, news[0].Score)
+	assert.Equal(t, 5, news[0].Comments)
+	assert.Equal(t, "hn", news[0].Source)
+	assert.Equal(t, "pg", news[0].Author)
+	assert.Equal(t, 2018, news[0].PublishedAt.Year())
+
+	assert.Equal(t, 0, news[1].Score)
+	assert.Equal(t, HackerNewsURL+"/item?id=102", news[1].URL)
+}
+
+func TestHackerNewsFetchMode(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/show", r.URL.Path)
+		w.Write([]byte(hackerNewsPage))
+	}))
+	defer done()
+
+	news, err := (&HackerNewsSource{Client: client, Mode: "show"}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	_, err = (&HackerNewsSource{Client: client, Mode: "worst"}).Fetch(context.Background(), 2)
+	assert.NotNil(t, err)
+}
+
+func TestHackerNewsFetchPages(t *testing.T) {
+	var pages []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("p")
+		pages = append(pages, page)
+
+		w.Write([]byte("<table>"))
+		for i := 0; i < 30; i++ {
+			fmt.Fprintf(w, `<tr class="athing" id="%s%02d"><td><span class="titleline"><a href="https://example.com/%s/%d">Story</a></span></td></tr><tr></tr>`, page, i, page, i)
+		}
+		w.Write([]byte("</table>"))
+	}))
+	defer done()
+
+	news, err := (&HackerNewsSource{Client: client, Workers: 1}).Fetch(context.Background(), 45)
+	assert.Nil(t, err)
+	assert.Len(t, news, 45)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, "https://example.com/2/14", news[44].URL)
+}
+
+func TestHackerNewsFetchFailing(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "slow down", http.StatusServiceUnavailable)
+	}))
+	defer done()
+
+	news, err := (&HackerNewsSource{Client: client}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+	assert.Empty(t, news)
+}
@@ -0,0 +1,125 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HackerNewsAPIURL is the base of the official Hacker News API
+const HackerNewsAPIURL = "https://hacker-news.firebaseio.com/v0"
+
+// hackerNewsAPIModes maps the listing modes to their endpoints
+var hackerNewsAPIModes = map[string]string{
+	"top":    "topstories",
+	"best":   "beststories",
+	"newest": "newstories",
+	"ask":    "askstories",
+	"show":   "showstories",
+	"jobs":   "jobstories",
+}
+
+func init() {
+	Register(Source{
+		Name:        "hn-api",
+		Description: "Hacker News top stories from the official API",
+		Flags:       []string{"mode"},
+		New: func(opts Options) Fetcher {
+			return &HackerNewsAPISource{Client: opts.Client, Workers: opts.Workers, Mode: opts.Mode}
+		},
+	})
+}
+
+// hackerNewsItem is a story as returned by the item endpoint
+type hackerNewsItem struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by"`
+	Time        int64  `json:"time"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Score       int    `json:"score"`
+	Descendants int    `json:"descendants"`
+	Deleted     bool   `json:"deleted"`
+	Dead        bool   `json:"dead"`
+}
+
+// HackerNewsAPISource fetches the top stories from hacker-news.firebaseio.com,
+// which unlike the front page markup doesn't change under our feet.
+type HackerNewsAPISource struct {
+	Client *http.Client
+	// Workers is the number of items fetched at once
+	Workers int
+	// Mode selects the listing: top (default), best, newest, ask, show or jobs
+	Mode string
+}
+
+// Fetch gets the top stories from the Hacker News API
+func (hn *HackerNewsAPISource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	listing, err := lookupMode("hn-api mode", hn.Mode, "top", hackerNewsAPIModes)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	if err := getJSON(ctx, hn.Client, HackerNewsAPIURL+"/"+listing+".json", &ids); err != nil {
+		return nil, err
+	}
+
+	// dead and deleted items are made up for with the following ones,
+	// until the listing runs out
+	var news []Story
+	var errs []error
+	for next := 0; len(news) < count && next < len(ids); {
+		end := next + count - len(news)
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch, err := fetchPages(ctx, next, end-1, hn.Workers, func(ctx context.Context, i int) ([]Story, error) {
+			return hn.fetchItem(ctx, ids[i])
+		})
+		news = append(news, batch...)
+		if ctx.Err() != nil {
+			return news, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		next = end
+	}
+	return news, errors.Join(errs...)
+}
+
+// fetchItem gets a single story, returning nothing for dead or deleted items
+func (hn *HackerNewsAPISource) fetchItem(ctx context.Context, id int) ([]Story, error) {
+	var item hackerNewsItem
+	if err := getJSON(ctx, hn.Client, fmt.Sprintf("%s/item/%d.json", HackerNewsAPIURL, id), &item); err != nil {
+		return nil, err
+	}
+
+	if item.Deleted || item.Dead || item.Title == "" {
+		return nil, nil
+	}
+
+	comments := HackerNewsItemURL + strconv.Itoa(item.ID)
+	url := item.URL
+	// Ask HN and friends link to their own discussion
+	if url == "" {
+		url = comments
+	}
+
+	return []Story{{
+		Title:       item.Title,
+		URL:         url,
+		CommentsURL: comments,
+		Score:       item.Score,
+		HasScore:    true,
+		Comments:    item.Descendants,
+		Author:      item.By,
+		Source:      "hn-api",
+		PublishedAt: time.Unix(item.Time, 0).UTC(),
+	}}, nil
+}
@@ -0,0 +1,61 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHackerNewsAPIFetch(t *testing.T) {
+	items := map[string]string{
+		"/v0/topstories.json": `[1, 2, 3, 4]`,
+		"/v0/item/1.json":     `{"id": 1, "type": "story", "by": "pg", "time": 1538492645, "title": "One", "url": "https://example.com/1", "score": 10, "descendants": 4}`,
+		"/v0/item/2.json":     `{"id": 2, "deleted": true}`,
+		"/v0/item/3.json":     `{"id": 3, "type": "story", "time": 1538492645, "title": "Ask HN: Three", "score": 3}`,
+		"/v0/item/4.json":     `{"id": 4, "type": "story", "time": 1538492645, "title": "Four", "url": "https://example.com/4"}`,
+	}
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(items[r.URL.Path]))
+	}))
+	defer done()
+
+	news, err := (&HackerNewsAPISource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	assert.Equal(t, "One", news[0].Title)
+	assert.Equal(t, "https://example.com/1", news[0].URL)
+	assert.Equal(t, HackerNewsItemURL+"1", news[0].CommentsURL)
+	assert.Equal(t, 10, news[0].Score)
+	assert.Equal(t, 4, news[0].Comments)
+	assert.Equal(t, "hn-api", news[0].Source)
+	assert.Equal(t, "pg", news[0].Author)
+	assert.Equal(t, int64(1538492645), news[0].PublishedAt.Unix())
+
+	// the deleted item is skipped and Ask HN links to its discussion
+	assert.Equal(t, HackerNewsItemURL+"3", news[1].URL)
+
+	news, err = (&HackerNewsAPISource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+
+	// a run of dead items doesn't end the listing early
+	items["/v0/newstories.json"] = `[2, 5, 6, 4, 1]`
+	items["/v0/item/5.json"] = `{"id": 5, "dead": true, "title": "Spam"}`
+	items["/v0/item/6.json"] = `{"id": 6, "deleted": true}`
+	news, err = (&HackerNewsAPISource{Client: client, Mode: "newest"}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "Four", news[0].Title)
+	news, err = (&HackerNewsAPISource{Client: client, Mode: "newest"}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://example.com/4", "https://example.com/1"}, urlsOf(news))
+
+	items["/v0/jobstories.json"] = `[4]`
+	news, err = (&HackerNewsAPISource{Client: client, Mode: "jobs"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "Four", news[0].Title)
+}
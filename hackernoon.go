@@ -0,0 +1,41 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// HackerNoonURL is the Hacker Noon homepage
+const HackerNoonURL = "https://hackernoon.com"
+
+func init() {
+	Register(Source{
+		Name:        "hackernoon",
+		Description: "Latest stories from hackernoon.com, optionally by tag",
+		Flags:       []string{"tags"},
+		New: func(opts Options) Fetcher {
+			return &HackerNoonSource{Client: opts.Client, Tags: opts.Tags}
+		},
+	})
+}
+
+// HackerNoonSource fetches latest stories from https://hackernoon.com/
+type HackerNoonSource struct {
+	Client *http.Client
+	// Tags restricts the stories to these tags, like "golang"
+	Tags []string
+}
+
+// Fetch gets news from Hacker Noon. With tags, the feed of every tag is read
+// and their stories are interleaved.
+func (l *HackerNoonSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if len(l.Tags) == 0 {
+		return fetchFeed(ctx, l.Client, HackerNoonURL+"/feed", "hackernoon", count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Tags)-1, len(l.Tags), func(ctx context.Context, i int) ([]Story, error) {
+		return fetchFeed(ctx, l.Client, HackerNoonURL+"/tagged/"+url.PathEscape(l.Tags[i])+"/feed", "hackernoon", count)
+	})
+	return interleave(lists, count), err
+}
@@ -0,0 +1,180 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// historyBucket holds one HistoryEntry per story, keyed by the CanonicalURL
+// of the story
+var historyBucket = []byte("stories")
+
+// historyTimeout is how long opening the history waits for another hnreader
+// holding it
+const historyTimeout = time.Second
+
+// HistoryFile returns the path of history.db inside ConfigDir, which records
+// the stories fetched and opened.
+func HistoryFile() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.db"), nil
+}
+
+// HistoryEntry is what the history knows about a story.
+type HistoryEntry struct {
+	// Story is the story as it was last fetched or opened
+	Story     Story     `json:"story"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// Opened is when the story was last opened or marked as read, zero
+	// when it never was
+	Opened time.Time `json:"opened,omitempty"`
+	// Opens counts how many times the story was opened
+	Opens int `json:"opens,omitempty"`
+}
+
+// History records every story fetched and opened in an embedded database,
+// which also keeps the queue of stories to read later.
+type History struct {
+	db *bolt.DB
+}
+
+// OpenHistory opens the history at path, creating it when it doesn't exist.
+// Only one process can have it open at once, the others fail after waiting
+// a second.
+func OpenHistory(path string) (*History, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: historyTimeout})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{historyBucket, laterBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &History{db: db}, nil
+}
+
+// Close closes the database.
+func (h *History) Close() error {
+	return h.db.Close()
+}
+
+// update changes the entries of the stories with change, in one transaction
+func (h *History) update(news []Story, at time.Time, change func(entry *HistoryEntry)) error {
+	return h.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		for _, story := range news {
+			key := []byte(story.articleKey())
+			entry := HistoryEntry{FirstSeen: at}
+			if data := b.Get(key); data != nil {
+				if err := json.Unmarshal(data, &entry); err != nil {
+					return err
+				}
+			}
+			entry.Story, entry.LastSeen = story, at
+			change(&entry)
+
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(key, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RecordFetched records that the stories were fetched at the given time.
+func (h *History) RecordFetched(news []Story, at time.Time) error {
+	return h.update(news, at, func(entry *HistoryEntry) {})
+}
+
+// RecordOpened records that the stories were opened at the given time.
+func (h *History) RecordOpened(news []Story, at time.Time) error {
+	return h.update(news, at, func(entry *HistoryEntry) {
+		entry.Opened = at
+		entry.Opens++
+	})
+}
+
+// MarkRead records that the stories were read at the given time, without
+// counting them as opened.
+func (h *History) MarkRead(news []Story, at time.Time) error {
+	return h.update(news, at, func(entry *HistoryEntry) {
+		entry.Opened = at
+	})
+}
+
+// ReadURLs returns the canonical URLs of the stories opened or marked as
+// read, for IsRead.
+func (h *History) ReadURLs() (map[string]bool, error) {
+	read := make(map[string]bool)
+	err := h.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(historyBucket).ForEach(func(key, data []byte) error {
+			var entry HistoryEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return err
+			}
+			if !entry.Opened.IsZero() {
+				read[string(key)] = true
+			}
+			return nil
+		})
+	})
+	return read, err
+}
+
+// Lookup returns the entry of the story, and whether there is one.
+func (h *History) Lookup(story Story) (HistoryEntry, bool, error) {
+	var entry HistoryEntry
+	found := false
+	err := h.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(historyBucket).Get([]byte(story.articleKey()))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &entry)
+	})
+	return entry, found, err
+}
+
+// Entries returns every entry of the history, the last seen first.
+func (h *History) Entries() ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	err := h.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(historyBucket).ForEach(func(key, data []byte) error {
+			var entry HistoryEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			return nil
+		})
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastSeen.After(entries[j].LastSeen)
+	})
+	return entries, err
+}
@@ -0,0 +1,57 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config", "history.db")
+
+	h, err := OpenHistory(path)
+	assert.Nil(t, err)
+
+	morning := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	noon := morning.Add(4 * time.Hour)
+	first := Story{Title: "First", URL: "https://example.com/1", Source: "hn", Score: 10}
+	second := Story{Title: "Second", URL: "https://example.com/2", Source: "lobsters"}
+	assert.Nil(t, h.RecordFetched([]Story{first, second}, morning))
+
+	first.Score = 50
+	assert.Nil(t, h.RecordOpened([]Story{first}, noon))
+	assert.Nil(t, h.RecordOpened([]Story{first}, noon))
+	assert.Nil(t, h.Close())
+
+	// entries outlive the process that wrote them
+	h, err = OpenHistory(path)
+	assert.Nil(t, err)
+	defer h.Close()
+
+	entry, found, err := h.Lookup(Story{URL: "http://www.example.com/1/"})
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 50, entry.Story.Score)
+	assert.Equal(t, morning, entry.FirstSeen.UTC())
+	assert.Equal(t, noon, entry.LastSeen.UTC())
+	assert.Equal(t, noon, entry.Opened.UTC())
+	assert.Equal(t, 2, entry.Opens)
+
+	_, found, err = h.Lookup(Story{URL: "https://example.com/3"})
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	entries, err := h.Entries()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "First", entries[0].Story.Title)
+	assert.Equal(t, "Second", entries[1].Story.Title)
+	assert.True(t, entries[1].Opened.IsZero())
+}
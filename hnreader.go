@@ -0,0 +1,52 @@
+// Package hnreader fetches tech news stories from a number of sources and
+// opens them in a browser. The command line tool lives in cmd/hnreader.
+package hnreader
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// App information and constants
+const (
+	AppName        = "hnreader"
+	AppVersion     = "v1.1"
+	AppAuthor      = "Bunchhieng Soth"
+	AppEmail       = "Bunchhieng@gmail.com"
+	AppDescription = "Open multiple tech news feeds in your favorite browser through the command line."
+)
+
+// Colors for console output
+var blue = color.New(color.FgBlue, color.Bold).SprintFunc()
+var red = color.New(color.FgRed, color.Bold).SprintFunc()
+
+// App contains author information
+type App struct {
+	Name, Version, Email, Description, Author string
+}
+
+// Init initializes the app
+func Init() *App {
+	return &App{
+		Name:        AppName,
+		Version:     AppVersion,
+		Description: AppDescription,
+		Author:      AppAuthor,
+		Email:       AppEmail,
+	}
+}
+
+// Information prints out app information
+func (app *App) Information() {
+	fmt.Println(blue(app.Name) + " - " + blue(app.Version))
+	fmt.Println(blue(app.Description))
+}
+
+// handleError go convention
+func handleError(err error) error {
+	if err != nil {
+		fmt.Println(red(err.Error()))
+	}
+	return nil
+}
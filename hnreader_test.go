@@ -1,6 +1,7 @@
-package main
+package hnreader
 
 import (
+	"context"
 	"log"
 	"reflect"
 	"testing"
@@ -17,18 +18,18 @@ func TestInit(t *testing.T) {
 }
 
 func TestGetHNStories(t *testing.T) {
-	news, err := new(HackerNewsSource).Fetch(10)
+	news, err := new(HackerNewsSource).Fetch(context.Background(), 10)
 	assert.Nil(t, err)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	assert.NotNil(t, news)
-	assert.Equal(t, 30, len(news), "They should be equal")
+	assert.Equal(t, 10, len(news), "They should be equal")
 }
 
 func TestGetRedditStories(t *testing.T) {
-	news, err := new(RedditSource).Fetch(10)
+	news, err := new(RedditSource).Fetch(context.Background(), 10)
 	assert.Nil(t, err)
 	if err != nil {
 		log.Fatal(err)
@@ -39,7 +40,7 @@ func TestGetRedditStories(t *testing.T) {
 }
 
 func TestGetLobstersStories(t *testing.T) {
-	news, err := new(LobstersSource).Fetch(10)
+	news, err := new(LobstersSource).Fetch(context.Background(), 10)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -49,7 +50,7 @@ func TestGetLobstersStories(t *testing.T) {
 }
 
 func TestGetDZoneStories(t *testing.T) {
-	news, err := new(DZoneSource).Fetch(10)
+	news, err := new(DZoneSource).Fetch(context.Background(), 10)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -59,7 +60,7 @@ func TestGetDZoneStories(t *testing.T) {
 }
 
 func TestGetDevToStories(t *testing.T) {
-	news, err := new(DevToSource).Fetch(10)
+	news, err := new(DevToSource).Fetch(context.Background(), 10)
 	assert.Nil(t, err)
 	if err != nil {
 		log.Fatal(err)
@@ -93,3 +94,22 @@ func TestGetBrowserNameByOS(t *testing.T) {
 	assert.Equal(t, "chrome", getBrowserNameByOS("google", os), assertErrMsg)
 	assert.Equal(t, "brave", getBrowserNameByOS("brave", os), assertErrMsg)
 }
+
+func TestGetClipboardCommandsForOS(t *testing.T) {
+	assert.Equal(t, [][]string{{"pbcopy"}}, getClipboardCommandsForOS(OSDarwin, false))
+	assert.Equal(t, [][]string{{"clip"}}, getClipboardCommandsForOS(OSWindows, false))
+	assert.Equal(t, "xclip", getClipboardCommandsForOS(OSLinux, false)[0][0])
+	assert.Equal(t, "wl-copy", getClipboardCommandsForOS(OSLinux, true)[0][0])
+}
+
+func TestFetchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	news, err := new(LobstersSource).Fetch(ctx, 30)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, news)
+
+	_, err = new(DZoneSource).Fetch(ctx, 10)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,150 @@
+package hnreader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// userAgent identifies hnreader to the sites it talks to
+var userAgent = fmt.Sprintf("desktop:com.github.Bunchhieng.%s:%s", AppName, AppVersion)
+
+// ClientOptions tunes the transport of the HTTP client shared by the sources.
+// Zero values keep the net/http defaults.
+type ClientOptions struct {
+	// Proxy is the URL of the proxy to use. The HTTP_PROXY and HTTPS_PROXY
+	// environment variables are honoured when it is empty.
+	Proxy string
+	// TLSHandshakeTimeout limits the time spent on a TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout limits the wait for a server's response headers.
+	ResponseHeaderTimeout time.Duration
+	// MaxIdleConnsPerHost is the number of keep-alive connections per host.
+	MaxIdleConnsPerHost int
+	// CacheDir enables the on-disk response cache in that directory.
+	CacheDir string
+	// Retries is how many times failed requests are retried.
+	Retries int
+	// RetryBackoff is the delay before the first retry, doubling after
+	// every attempt. DefaultRetryBackoff is used when it is zero.
+	RetryBackoff time.Duration
+	// RetryJitter randomises retry delays by up to this fraction.
+	RetryJitter float64
+	// Rate limits the requests per second sent to each host, zero
+	// disables the limit.
+	Rate float64
+	// Burst is how many requests a host may get at once, DefaultBurst
+	// when zero.
+	Burst int
+	// HostRates overrides Rate for individual hosts, DefaultHostRates
+	// when nil.
+	HostRates map[string]float64
+}
+
+// NewClient builds an HTTP client from the given options.
+func NewClient(opts ClientOptions) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %v", opts.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	if opts.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+
+	var rt http.RoundTripper = transport
+	if opts.Rate > 0 {
+		burst := opts.Burst
+		if burst <= 0 {
+			burst = DefaultBurst
+		}
+		hostRates := opts.HostRates
+		if hostRates == nil {
+			hostRates = DefaultHostRates
+		}
+		rt = &RateLimitTransport{Rate: opts.Rate, Burst: burst, HostRates: hostRates, Transport: rt}
+	}
+	if opts.Retries > 0 {
+		backoff := opts.RetryBackoff
+		if backoff <= 0 {
+			backoff = DefaultRetryBackoff
+		}
+		rt = &RetryTransport{Retries: opts.Retries, Backoff: backoff, Jitter: opts.RetryJitter, Transport: rt}
+	}
+	if opts.CacheDir != "" {
+		rt = &CacheTransport{Dir: opts.CacheDir, Transport: rt}
+	}
+
+	return &http.Client{Transport: rt}, nil
+}
+
+// getJSON fetches url and decodes the JSON response into v
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	return getJSONWithHeader(ctx, client, url, nil, v)
+}
+
+// getJSONWithHeader is getJSON sending extra request headers
+func getJSONWithHeader(ctx context.Context, client *http.Client, url string, header http.Header, v interface{}) error {
+	resp, err := getWithHeader(ctx, client, url, header)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// get issues a GET request for url with client, or http.DefaultClient when
+// client is nil. The request is cancelled together with ctx and responses
+// other than 200 OK are turned into errors.
+func get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	return getWithHeader(ctx, client, url, nil)
+}
+
+// getWithHeader is get sending extra request headers
+func getWithHeader(ctx context.Context, client *http.Client, url string, header http.Header) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	return resp, nil
+}
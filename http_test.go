@@ -0,0 +1,93 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rewriteTransport sends every request to the test server, whatever its URL
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u := *req.URL
+	u.Scheme = t.target.Scheme
+	u.Host = t.target.Host
+
+	r := new(http.Request)
+	*r = *req
+	r.URL = &u
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestClient returns a client whose requests are all served by handler,
+// and a function that shuts the test server down
+func newTestClient(handler http.Handler) (*http.Client, func()) {
+	server := httptest.NewServer(handler)
+	target, _ := url.Parse(server.URL)
+	return &http.Client{Transport: rewriteTransport{target}}, server.Close
+}
+
+func TestGet(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer done()
+
+	resp, err := get(context.Background(), client, "https://example.com/")
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	_, err = get(context.Background(), client, "https://example.com/missing")
+	assert.EqualError(t, err, "https://example.com/missing: 404 Not Found")
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(ClientOptions{
+		Proxy:                 "http://localhost:3128",
+		ResponseHeaderTimeout: time.Second,
+		MaxIdleConnsPerHost:   4,
+	})
+	assert.Nil(t, err)
+
+	transport := client.Transport.(*http.Transport)
+	assert.NotNil(t, transport)
+	assert.Equal(t, time.Second, transport.ResponseHeaderTimeout)
+	assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	proxy, _ := transport.Proxy(req)
+	assert.Equal(t, "localhost:3128", proxy.Host)
+
+	_, err = NewClient(ClientOptions{Proxy: "://bad"})
+	assert.NotNil(t, err)
+}
+
+func TestNewClientLayers(t *testing.T) {
+	client, err := NewClient(ClientOptions{CacheDir: "/tmp/hnreader", Retries: 3, Rate: 1})
+	assert.Nil(t, err)
+
+	cache := client.Transport.(*CacheTransport)
+	assert.Equal(t, "/tmp/hnreader", cache.Dir)
+
+	retry := cache.Transport.(*RetryTransport)
+	assert.Equal(t, 3, retry.Retries)
+	assert.Equal(t, DefaultRetryBackoff, retry.Backoff)
+
+	limit := retry.Transport.(*RateLimitTransport)
+	assert.Equal(t, 1.0, limit.Rate)
+	assert.Equal(t, DefaultBurst, limit.Burst)
+	assert.IsType(t, new(http.Transport), limit.Transport)
+}
@@ -0,0 +1,65 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// IndieHackersURL is the Indie Hackers homepage
+const IndieHackersURL = "https://www.indiehackers.com"
+
+func init() {
+	Register(Source{
+		Name:        "indiehackers",
+		Description: "Popular posts from indiehackers.com",
+		New: func(opts Options) Fetcher {
+			return &IndieHackersSource{Client: opts.Client}
+		},
+	})
+}
+
+// IndieHackersSource scrapes the popular posts of https://www.indiehackers.com/
+type IndieHackersSource struct {
+	Client *http.Client
+}
+
+// Fetch gets the popular posts of the homepage
+func (l *IndieHackersSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	resp, err := get(ctx, l.Client, IndieHackersURL+"/?sort=popular")
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var news []Story
+	// the markup changes often, posts are recognised by their address and
+	// appear more than once for the title, avatar and comment links
+	doc.Find(`a[href^="/post/"]`).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		href = IndieHackersURL + strings.SplitN(href, "#", 2)[0]
+		title := strings.TrimSpace(s.Text())
+		if seen[href] || title == "" {
+			return
+		}
+		seen[href] = true
+
+		news = append(news, Story{
+			Title:       title,
+			URL:         href,
+			CommentsURL: href,
+			Source:      "indiehackers",
+		})
+	})
+
+	return truncate(news, count), nil
+}
@@ -0,0 +1,36 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const indieHackersPage = `<html><body>
+<div class="feed-item">
+	<a href="/post/first-post-abc"><img src="avatar.png"></a>
+	<a class="feed-item__title-link" href="/post/first-post-abc"> How I got my first customer </a>
+	<a href="/post/first-post-abc#comments">12 comments</a>
+</div>
+<div class="feed-item">
+	<a class="feed-item__title-link" href="/post/second-def">Launching today</a>
+</div>
+<a href="/products">Products</a>
+</body></html>`
+
+func TestIndieHackersFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(indieHackersPage))
+	}))
+	defer done()
+
+	news, err := (&IndieHackersSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "How I got my first customer", news[0].Title)
+		assert.Equal(t, IndieHackersURL+"/post/first-post-abc", news[0].URL)
+		assert.Equal(t, "Launching today", news[1].Title)
+	}
+}
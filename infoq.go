@@ -0,0 +1,48 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// InfoQURL is the InfoQ feed, topic feeds live below it
+const InfoQURL = "https://feed.infoq.com/"
+
+// infoQTopics maps the topics to their feed paths
+var infoQTopics = map[string]string{
+	"all":          "",
+	"ai-ml":        "ai-ml-data-eng/",
+	"architecture": "architecture-design/",
+	"cloud":        "cloud-computing/",
+	"culture":      "culture-methods/",
+	"development":  "development/",
+	"devops":       "devops/",
+	"java":         "java/",
+}
+
+func init() {
+	Register(Source{
+		Name:        "infoq",
+		Description: "Latest articles from infoq.com, optionally on one topic",
+		Flags:       []string{"topic"},
+		New: func(opts Options) Fetcher {
+			return &InfoQSource{Client: opts.Client, Topic: opts.Topic}
+		},
+	})
+}
+
+// InfoQSource fetches latest stories from https://www.infoq.com/
+type InfoQSource struct {
+	Client *http.Client
+	// Topic such as architecture, ai-ml or java, all of them by default
+	Topic string
+}
+
+// Fetch gets news from InfoQ
+func (l *InfoQSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	path, err := lookupMode("infoq topic", l.Topic, "all", infoQTopics)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFeed(ctx, l.Client, InfoQURL+path, "infoq", count)
+}
@@ -0,0 +1,66 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// jsonFeed decodes a JSON Feed document, see https://jsonfeed.org
+type jsonFeed struct {
+	Version string         `json:"version"`
+	Items   []jsonFeedItem `json:"items"`
+}
+
+// jsonFeedItem is a single item of a JSON Feed
+type jsonFeedItem struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	ExternalURL   string `json:"external_url"`
+	Title         string `json:"title"`
+	DatePublished string `json:"date_published"`
+	DateModified  string `json:"date_modified"`
+}
+
+// Story converts the item into a Story from the given source. Link blogs
+// point external_url at the article and url at their own post about it.
+func (item jsonFeedItem) Story(source string) Story {
+	story := Story{
+		Title:       strings.TrimSpace(item.Title),
+		URL:         strings.TrimSpace(item.URL),
+		Source:      source,
+		PublishedAt: parseTime(item.DatePublished),
+	}
+	if item.ExternalURL != "" {
+		story.CommentsURL = story.URL
+		story.URL = strings.TrimSpace(item.ExternalURL)
+	}
+	if story.PublishedAt.IsZero() {
+		story.PublishedAt = parseTime(item.DateModified)
+	}
+	return story
+}
+
+// parseJSONFeed reads the stories of a JSON Feed document
+func parseJSONFeed(r io.Reader, source string) ([]Story, error) {
+	doc := jsonFeed{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, item := range doc.Items {
+		news = append(news, item.Story(source))
+	}
+	return news, nil
+}
+
+// isJSONFeed tells JSON Feed documents apart from XML ones, by their
+// content type or else by their first character
+func isJSONFeed(contentType string, start []byte) bool {
+	if strings.Contains(contentType, "json") {
+		return true
+	}
+	trimmed := strings.TrimSpace(string(start))
+	return strings.HasPrefix(trimmed, "{")
+}
@@ -0,0 +1,51 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonFeedDoc = `{
+	"version": "https://jsonfeed.org/version/1",
+	"title": "Links",
+	"items": [
+		{"id": "1", "url": "https://example.org/1", "title": "Own post", "date_published": "2018-10-02T15:04:05Z"},
+		{"id": "2", "url": "https://example.org/2", "external_url": "https://example.com/article", "title": " Link ", "date_modified": "2018-10-03T15:04:05Z"}
+	]
+}`
+
+func TestParseJSONFeed(t *testing.T) {
+	news, err := parseJSONFeed(strings.NewReader(jsonFeedDoc), "rss")
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "https://example.org/1", news[0].URL)
+		assert.Equal(t, "", news[0].CommentsURL)
+		assert.Equal(t, 2, news[0].PublishedAt.Day())
+		assert.Equal(t, "Link", news[1].Title)
+		assert.Equal(t, "https://example.com/article", news[1].URL)
+		assert.Equal(t, "https://example.org/2", news[1].CommentsURL)
+		assert.Equal(t, 3, news[1].PublishedAt.Day())
+	}
+}
+
+func TestIsJSONFeed(t *testing.T) {
+	assert.True(t, isJSONFeed("application/feed+json", []byte("")))
+	assert.True(t, isJSONFeed("text/plain", []byte("\n  {\"version\"")))
+	assert.False(t, isJSONFeed("application/xml", []byte("<?xml")))
+}
+
+func TestFeedSourceFetchJSON(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/feed+json")
+		w.Write([]byte(jsonFeedDoc))
+	}))
+	defer done()
+
+	news, err := (&FeedSource{Client: client, URL: "https://example.org/feed.json"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+}
@@ -0,0 +1,131 @@
+package hnreader
+
+import (
+	"strings"
+	"unicode"
+)
+
+// scripts maps the writing systems telling a language on their own to
+// its ISO 639-1 code. Han is left out as Japanese is written with it too.
+var scripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+}
+
+// stopwords are the short words that give away the languages written with
+// the Latin alphabet, even in a title
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "for", "with", "how", "why", "what", "you", "your", "from", "this", "that", "are", "on", "an", "my", "we", "it", "about", "into"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "ein", "eine", "auf", "den", "dem", "zu", "von", "wie", "warum", "sich", "auch"},
+	"fr": {"le", "la", "les", "et", "est", "des", "une", "du", "pour", "avec", "dans", "sur", "pas", "qui", "que", "au", "aux", "comment", "pourquoi"},
+	"es": {"el", "los", "las", "y", "es", "del", "una", "por", "para", "con", "cómo", "qué", "en", "se", "que", "al", "más"},
+	"pt": {"o", "os", "as", "e", "é", "do", "da", "dos", "das", "uma", "para", "com", "não", "como", "em", "que", "por"},
+	"it": {"il", "lo", "gli", "e", "è", "di", "del", "della", "una", "per", "con", "non", "che", "come", "perché", "sono"},
+	"nl": {"het", "een", "en", "van", "is", "niet", "met", "voor", "op", "zijn", "hoe", "waarom", "dat"},
+}
+
+// letters are the letters only found in one of the languages above
+var letters = map[rune]string{
+	'ß': "de", 'ä': "de", 'ö': "de", 'ü': "de",
+	'ñ': "es", '¿': "es", '¡': "es",
+	'ç': "fr", 'œ': "fr", 'û': "fr",
+	'ã': "pt", 'õ': "pt",
+}
+
+// DetectLanguage guesses the language text is written in, as an ISO 639-1
+// code like "en", from its script or its most common words. It returns ""
+// when it can't tell, which is often the case for titles made of names.
+func DetectLanguage(text string) string {
+	text = strings.ToLower(text)
+
+	// letters of other scripts outweigh the English names in the text
+	written := make(map[string]int)
+	hints := make(map[string]int)
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			written["zh"]++
+			continue
+		}
+		if lang, ok := letters[r]; ok {
+			hints[lang]++
+		}
+		for _, script := range scripts {
+			if unicode.Is(script.table, r) {
+				written[script.lang]++
+				break
+			}
+		}
+	}
+	if len(written) > 0 {
+		// kana are only used along with Han characters in Japanese
+		if written["ja"] > 0 {
+			return "ja"
+		}
+		return most(written, "zh", "ko", "ru", "el", "ar", "he", "hi", "th")
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	counts := make(map[string]int)
+	for lang, list := range stopwords {
+		for _, word := range words {
+			for _, stopword := range list {
+				if word == stopword {
+					counts[lang]++
+				}
+			}
+		}
+	}
+	latin := []string{"en", "de", "fr", "es", "pt", "it", "nl"}
+	if lang := most(counts, latin...); lang != "" {
+		return lang
+	}
+	return most(hints, latin...)
+}
+
+// most returns the one of langs with the highest count, or "" when none
+// of them was counted or several are tied
+func most(counts map[string]int, langs ...string) string {
+	best, tied := "", false
+	for _, lang := range langs {
+		switch {
+		case counts[lang] == 0:
+		case best == "" || counts[lang] > counts[best]:
+			best, tied = lang, false
+		case counts[lang] == counts[best]:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
+// InLanguages keeps the stories whose title is in any of langs, given as
+// ISO 639-1 codes, along with those whose language can't be told.
+func InLanguages(langs []string) Filter {
+	return func(story Story) bool {
+		lang := DetectLanguage(story.Title)
+		if lang == "" {
+			return true
+		}
+		for _, wanted := range langs {
+			if strings.EqualFold(lang, wanted) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package hnreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	for text, lang := range map[string]string{
+		"How we cut our build times in half":          "en",
+		"Why the web is getting slower":               "en",
+		"Warum die Bahn nicht pünktlich ist":          "de",
+		"Comment écrire un compilateur pour le web":   "fr",
+		"Cómo funciona el recolector de basura de Go": "es",
+		"Como funciona a coleta de lixo do Go":        "pt",
+		"Perché il software non è mai finito":         "it",
+		"Waarom het internet niet werkt":              "nl",
+		"Rust 1.80 をリリースしました":                         "ja",
+		"谷歌发布新的编程语言":                                  "zh",
+		"Новый релиз Linux":                           "ru",
+		"한국어 자연어 처리":                                  "ko",
+		"Kubernetes 1.31":                             "",
+		"Straße":                                      "de",
+		"":                                            "",
+	} {
+		assert.Equal(t, lang, DetectLanguage(text), text)
+	}
+}
+
+func TestInLanguages(t *testing.T) {
+	news := []Story{
+		{Title: "How we cut our build times in half"},
+		{Title: "Warum die Bahn nicht pünktlich ist"},
+		{Title: "Kubernetes 1.31"},
+		{Title: "Comment écrire un compilateur pour le web"},
+	}
+	assert.Equal(t, []Story{news[0], news[2]}, InLanguages([]string{"EN"}).Apply(news))
+	assert.Equal(t, []Story{news[0], news[2], news[3]}, InLanguages([]string{"en", "fr"}).Apply(news))
+}
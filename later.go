@@ -0,0 +1,104 @@
+package hnreader
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// laterBucket holds the queue of stories to read later, keyed by their
+// sequence number in the bucket so that they are kept in queue order
+var laterBucket = []byte("later")
+
+// PushLater adds the stories at the end of the queue to read later, skipping
+// those queued already.
+func (h *History) PushLater(stories ...Story) error {
+	return h.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(laterBucket)
+		queued := make(map[string]bool)
+		err := b.ForEach(func(key, data []byte) error {
+			var story Story
+			if err := json.Unmarshal(data, &story); err != nil {
+				return err
+			}
+			queued[story.URL] = true
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, story := range stories {
+			if queued[story.URL] {
+				continue
+			}
+			queued[story.URL] = true
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(story)
+			if err != nil {
+				return err
+			}
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, seq)
+			if err := b.Put(key, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Later returns the queue of stories to read later, the first queued story
+// first.
+func (h *History) Later() ([]Story, error) {
+	var news []Story
+	err := h.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(laterBucket).ForEach(func(key, data []byte) error {
+			var story Story
+			if err := json.Unmarshal(data, &story); err != nil {
+				return err
+			}
+			news = append(news, story)
+			return nil
+		})
+	})
+	return news, err
+}
+
+// PopLater takes up to count stories off the queue to read later and
+// returns them: the first queued ones, or the last queued ones with lifo,
+// the most recent first.
+func (h *History) PopLater(count int, lifo bool) ([]Story, error) {
+	var popped []Story
+	err := h.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(laterBucket)
+		c := b.Cursor()
+		var keys [][]byte
+		key, data := c.First()
+		next := c.Next
+		if lifo {
+			key, data = c.Last()
+			next = c.Prev
+		}
+		for ; key != nil && len(popped) < count; key, data = next() {
+			var story Story
+			if err := json.Unmarshal(data, &story); err != nil {
+				return err
+			}
+			popped = append(popped, story)
+			keys = append(keys, append([]byte(nil), key...))
+		}
+		// deleting while iterating would skip items
+		for _, key := range keys {
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return popped, err
+}
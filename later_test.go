@@ -0,0 +1,53 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaterQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	h, err := OpenHistory(filepath.Join(dir, "history.db"))
+	assert.Nil(t, err)
+	defer h.Close()
+
+	var queue []Story
+	for _, title := range []string{"1", "2", "3", "4", "5"} {
+		queue = append(queue, Story{Title: title, URL: "https://example.com/" + title})
+	}
+	assert.Nil(t, h.PushLater(queue[:3]...))
+	assert.Nil(t, h.PushLater(queue[2:]...))
+
+	news, err := h.Later()
+	assert.Nil(t, err)
+	assert.Equal(t, queue, news)
+
+	popped, err := h.PopLater(2, false)
+	assert.Nil(t, err)
+	assert.Equal(t, queue[:2], popped)
+
+	popped, err = h.PopLater(2, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []Story{queue[4], queue[3]}, popped)
+
+	popped, err = h.PopLater(5, false)
+	assert.Nil(t, err)
+	assert.Equal(t, queue[2:3], popped)
+
+	popped, err = h.PopLater(5, true)
+	assert.Nil(t, err)
+	assert.Empty(t, popped)
+
+	// stories queued after popping go at the end
+	assert.Nil(t, h.PushLater(queue[0], queue[1]))
+	popped, err = h.PopLater(1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, queue[:1], popped)
+}
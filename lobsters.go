@@ -0,0 +1,106 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// LobstersURL is the Lobsters front page
+const LobstersURL = "https://lobste.rs"
+
+// lobstersModes maps the listing modes to their paths
+var lobstersModes = map[string]string{
+	"hottest": "",
+	"newest":  "/newest",
+	"recent":  "/recent",
+}
+
+func init() {
+	Register(Source{
+		Name:        "lobsters",
+		Description: "Hottest, newest or recent stories from lobste.rs",
+		Flags:       []string{"mode"},
+		New: func(opts Options) Fetcher {
+			return &LobstersSource{Client: opts.Client, Workers: opts.Workers, Mode: opts.Mode}
+		},
+	})
+}
+
+// LobstersSource fetches new stories from https://lobste.rs
+type LobstersSource struct {
+	Client *http.Client
+	// Workers is the number of pages fetched at once
+	Workers int
+	// Mode selects the listing: hottest (default), newest or recent
+	Mode string
+}
+
+// Fetch gets news from the Lobsters
+func (l *LobstersSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	listing, err := lookupMode("lobsters mode", l.Mode, "hottest", lobstersModes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 25 news per page
+	p := pager{perPage: 25, workers: l.Workers, fetch: func(ctx context.Context, page int) ([]Story, error) {
+		return l.fetchPage(ctx, listing, page)
+	}}
+	return p.collect(ctx, count)
+}
+
+// fetchPage scrapes a single page of stories from a listing
+func (l *LobstersSource) fetchPage(ctx context.Context, listing string, page int) ([]Story, error) {
+	url := fmt.Sprintf("%s%s/page/%d", LobstersURL, listing, page)
+	resp, err := get(ctx, l.Client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find("li.story").Each(func(_ int, s *goquery.Selection) {
+		link := s.Find(".link a.u-url")
+		href, exist := link.Attr("href")
+		if !exist {
+			return
+		}
+
+		// if internal link
+		if strings.HasPrefix(href, "/") {
+			href = LobstersURL + href
+		}
+
+		commentsLink := s.Find(".comments_label a")
+		comments, _ := commentsLink.Attr("href")
+		if strings.HasPrefix(comments, "/") {
+			comments = LobstersURL + comments
+		}
+		published, _ := s.Find(".byline span[title]").First().Attr("title")
+
+		news = append(news, Story{
+			Title:       strings.TrimSpace(link.Text()),
+			URL:         href,
+			CommentsURL: comments,
+			Score:       leadingInt(s.Find(".score").First().Text()),
+			HasScore:    true,
+			Comments:    leadingInt(commentsLink.Text()),
+			Author:      strings.TrimSpace(s.Find(".byline a.u-author").First().Text()),
+			Tags:        tags(s.Find(".tags a.tag").Map(func(_ int, tag *goquery.Selection) string { return tag.Text() })),
+			Source:      "lobsters",
+			PublishedAt: parseTime(published),
+		})
+	})
+
+	return news, nil
+}
@@ -0,0 +1,61 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lobstersPage = `<html><body><ol>
+<li class="story"><div class="score">17</div>
+<div class="details"><span class="link"><a class="u-url" href="https://example.com/a">A story</a></span>
+<span class="tags"><a class="tag tag_go" href="/t/go">go</a> <a class="tag tag_rust" href="/t/rust">rust</a></span>
+<div class="byline"><a class="u-author h-card" href="/~alice">alice</a> <span title="2018-10-02 15:04:05 -0500">3 hours ago</span>
+<span class="comments_label"><a href="/s/abc/a_story">4 comments</a></span></div></div></li>
+<li class="story"><div class="score">1</div>
+<div class="details"><span class="link"><a class="u-url">No link</a></span></div></li>
+<li class="story"><div class="score">3</div>
+<div class="details"><span class="link"><a class="u-url" href="/s/def/ask">Ask</a></span></div></li>
+</ol></body></html>`
+
+func TestLobstersFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/page/1", r.URL.Path)
+		w.Write([]byte(lobstersPage))
+	}))
+	defer done()
+
+	news, err := (&LobstersSource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	assert.Equal(t, "A story", news[0].Title)
+	assert.Equal(t, "https://example.com/a", news[0].URL)
+	assert.Equal(t, LobstersURL+"/s/abc/a_story", news[0].CommentsURL)
+	assert.Equal(t, 17, news[0].Score)
+	assert.Equal(t, 4, news[0].Comments)
+	assert.Equal(t, "lobsters", news[0].Source)
+	assert.Equal(t, "alice", news[0].Author)
+	assert.Equal(t, []string{"go", "rust"}, news[0].Tags)
+	assert.Nil(t, news[1].Tags)
+	assert.False(t, news[0].PublishedAt.IsZero())
+
+	assert.Equal(t, LobstersURL+"/s/def/ask", news[1].URL)
+}
+
+func TestLobstersFetchMode(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/newest/page/1", r.URL.Path)
+		w.Write([]byte(lobstersPage))
+	}))
+	defer done()
+
+	news, err := (&LobstersSource{Client: client, Mode: "newest"}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+
+	_, err = (&LobstersSource{Client: client, Mode: "coldest"}).Fetch(context.Background(), 1)
+	assert.EqualError(t, err, `unknown lobsters mode "coldest" (one of hottest, newest, recent)`)
+}
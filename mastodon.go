@@ -0,0 +1,90 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMastodonInstance is asked for trends when no instance is given
+const DefaultMastodonInstance = "mastodon.social"
+
+func init() {
+	Register(Source{
+		Name:        "mastodon",
+		Description: "Links trending on a Mastodon instance",
+		Flags:       []string{"instance"},
+		New: func(opts Options) Fetcher {
+			return &MastodonSource{Client: opts.Client, Workers: opts.Workers, Instance: opts.Instance}
+		},
+	})
+}
+
+// mastodonLink is a trending link as returned by /api/v1/trends/links
+type mastodonLink struct {
+	URL     string `json:"url"`
+	Title   string `json:"title"`
+	History []struct {
+		Day      string `json:"day"`
+		Uses     string `json:"uses"`
+		Accounts string `json:"accounts"`
+	} `json:"history"`
+}
+
+// MastodonSource fetches the links trending on a Mastodon instance
+type MastodonSource struct {
+	Client *http.Client
+	// Workers is the number of pages fetched at once
+	Workers int
+	// Instance is the host name of the server, DefaultMastodonInstance
+	// when empty
+	Instance string
+}
+
+// Fetch gets the trending links, most shared first
+func (l *MastodonSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	instance := l.Instance
+	if instance == "" {
+		instance = DefaultMastodonInstance
+	}
+	if !strings.Contains(instance, "://") {
+		instance = "https://" + instance
+	}
+
+	// the API returns at most 20 links at a time
+	p := pager{perPage: 20, workers: l.Workers, fetch: func(ctx context.Context, page int) ([]Story, error) {
+		return l.fetchPage(ctx, strings.TrimSuffix(instance, "/"), page)
+	}}
+	return p.collect(ctx, count)
+}
+
+// fetchPage gets a single page of trending links
+func (l *MastodonSource) fetchPage(ctx context.Context, instance string, page int) ([]Story, error) {
+	var links []mastodonLink
+	url := fmt.Sprintf("%s/api/v1/trends/links?limit=20&offset=%d", instance, (page-1)*20)
+	if err := getJSON(ctx, l.Client, url, &links); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, link := range links {
+		// the score is how many people shared the link recently
+		accounts := 0
+		for _, day := range link.History {
+			n, _ := strconv.Atoi(day.Accounts)
+			accounts += n
+		}
+
+		news = append(news, Story{
+			Title:    strings.TrimSpace(link.Title),
+			URL:      link.URL,
+			Score:    accounts,
+			HasScore: true,
+			Source:   "mastodon",
+		})
+	}
+
+	return news, nil
+}
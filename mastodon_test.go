@@ -0,0 +1,35 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mastodonTrends = `[
+	{"url": "https://example.com/one", "title": " One ", "history": [{"day": "1538438400", "uses": "30", "accounts": "20"}, {"day": "1538352000", "uses": "5", "accounts": "4"}]},
+	{"url": "https://example.com/two", "title": "Two", "history": []}
+]`
+
+func TestMastodonFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/trends/links", r.URL.Path)
+		if r.URL.Query().Get("offset") != "0" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(mastodonTrends))
+	}))
+	defer done()
+
+	news, err := (&MastodonSource{Client: client, Instance: "fosstodon.org"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "One", news[0].Title)
+		assert.Equal(t, "https://example.com/one", news[0].URL)
+		assert.Equal(t, 24, news[0].Score)
+		assert.Equal(t, "mastodon", news[0].Source)
+	}
+}
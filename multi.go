@@ -0,0 +1,205 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Ways MultiSource mixes its sources
+const (
+	// MixRoundRobin takes one story from each source in turn, letting the
+	// others make up for a source that runs out
+	MixRoundRobin = "round-robin"
+	// MixProportional gives every source an equal share of the stories and
+	// keeps each source's stories together
+	MixProportional = "proportional"
+)
+
+// MultiSource combines the stories of several sources.
+type MultiSource struct {
+	Fetchers []Fetcher
+	// Weights sets the share of the stories taken from each fetcher with
+	// MixProportional, they are equal when nil
+	Weights []float64
+	// Mix is MixRoundRobin or MixProportional. It defaults to
+	// MixProportional when there are weights, to MixRoundRobin otherwise.
+	Mix string
+	// PerSource caps the stories taken from each source when non-zero
+	PerSource int
+	// Batch is called with the stories of each source as soon as they are
+	// fetched, one source at a time, so they can be shown before the
+	// slowest source answers. A batch holds the source's share of the
+	// stories, without those linking to an article or having a title of
+	// an earlier batch. Fetch returns the stories mixed as usual.
+	Batch func(news []Story)
+}
+
+// Fetch gets the stories of every source at once and mixes them, keeping
+// one story per article as told by CanonicalURL, and the highest scored of
+// those with similar titles. A failing source is reported and left out.
+func (m *MultiSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if len(m.Fetchers) == 0 {
+		return nil, nil
+	}
+
+	mix := m.Mix
+	if mix == "" && m.Weights != nil {
+		mix = MixProportional
+	}
+
+	var quotas []int
+	switch mix {
+	case "", MixRoundRobin:
+	case MixProportional:
+		weights := m.Weights
+		if len(weights) != len(m.Fetchers) {
+			weights = equalWeights(len(m.Fetchers))
+		}
+		quotas = shares(count, weights)
+	default:
+		return nil, fmt.Errorf("unknown mix %q (one of %s, %s)", m.Mix, MixProportional, MixRoundRobin)
+	}
+
+	batches := newBatcher(m.Batch, count, quotas, len(m.Fetchers))
+	lists, err := fetchLists(ctx, 0, len(m.Fetchers)-1, len(m.Fetchers), func(ctx context.Context, i int) ([]Story, error) {
+		n := count
+		if quotas != nil {
+			n = quotas[i]
+		}
+		if m.PerSource > 0 && m.PerSource < n {
+			n = m.PerSource
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		news, err := m.Fetchers[i].Fetch(ctx, n)
+		batches.send(i, news)
+		return news, err
+	})
+	lists = collapseSimilarTitles(lists)
+
+	if quotas == nil {
+		return interleave(lists, count), err
+	}
+	return concat(lists, count), err
+}
+
+// batcher passes the stories of each source to MultiSource.Batch
+type batcher struct {
+	batch func(news []Story)
+	// shares are the number of stories in the batch of each source
+	shares []int
+	mu     sync.Mutex
+	sent   []Story
+	keys   map[string]bool
+}
+
+// newBatcher returns a batcher calling batch, which may be nil, with the
+// share of count of each of n sources: their quota when there are quotas,
+// an equal share otherwise
+func newBatcher(batch func(news []Story), count int, quotas []int, n int) *batcher {
+	if quotas == nil {
+		quotas = shares(count, equalWeights(n))
+	}
+	return &batcher{batch: batch, shares: quotas, keys: make(map[string]bool)}
+}
+
+// send calls batch with the share of the stories of source i that aren't
+// in earlier batches
+func (b *batcher) send(i int, news []Story) {
+	if b.batch == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// titles are only compared with those of other sources
+	earlier := b.sent
+	var batch []Story
+next:
+	for _, story := range news {
+		if len(batch) == b.shares[i] {
+			break
+		}
+		key := story.articleKey()
+		if b.keys[key] {
+			continue
+		}
+		for _, sent := range earlier {
+			if SimilarTitles(sent.Title, story.Title) {
+				continue next
+			}
+		}
+		b.keys[key] = true
+		b.sent = append(b.sent, story)
+		batch = append(batch, story)
+	}
+	if len(batch) > 0 {
+		b.batch(batch)
+	}
+}
+
+// concat joins the lists one after the other, skipping the stories linking
+// to an article already taken, up to count stories
+func concat(lists [][]Story, count int) []Story {
+	seen := make(map[string]bool)
+	var news []Story
+
+	for _, stories := range lists {
+		for _, story := range stories {
+			if len(news) == count {
+				return news
+			}
+			if !seen[story.articleKey()] {
+				seen[story.articleKey()] = true
+				news = append(news, story)
+			}
+		}
+	}
+	return news
+}
+
+// equalWeights returns n weights of 1
+func equalWeights(n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// shares splits count in parts proportional to weights, handing the
+// remainder out to the largest fractions so the parts add up to count
+func shares(count int, weights []float64) []int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	parts := make([]int, len(weights))
+	if total <= 0 {
+		return parts
+	}
+
+	fractions := make([]float64, len(weights))
+	given := 0
+	for i, w := range weights {
+		exact := float64(count) * w / total
+		parts[i] = int(exact)
+		fractions[i] = exact - float64(parts[i])
+		given += parts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return fractions[order[a]] > fractions[order[b]] })
+	for i := 0; given < count; i++ {
+		parts[order[i%len(order)]]++
+		given++
+	}
+	return parts
+}
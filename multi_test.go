@@ -0,0 +1,134 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// listSource returns count numbered stories with a prefix
+type listSource struct {
+	prefix string
+	size   int
+}
+
+func (l listSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	var news []Story
+	for i := 1; i <= count && i <= l.size; i++ {
+		news = append(news, Story{URL: fmt.Sprintf("%s%d", l.prefix, i)})
+	}
+	return news, nil
+}
+
+// staticSource returns its stories
+type staticSource []Story
+
+func (s staticSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return truncate(s, count), nil
+}
+
+// gatedSource waits for gate to be closed before answering
+type gatedSource struct {
+	Fetcher
+	gate chan struct{}
+}
+
+func (g gatedSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	<-g.gate
+	return g.Fetcher.Fetch(ctx, count)
+}
+
+func urlsOf(news []Story) []string {
+	var urls []string
+	for _, story := range news {
+		urls = append(urls, story.URL)
+	}
+	return urls
+}
+
+func TestMultiSourceRoundRobin(t *testing.T) {
+	m := &MultiSource{Fetchers: []Fetcher{listSource{"a", 10}, listSource{"b", 1}, listSource{"c", 10}}}
+	news, err := m.Fetch(context.Background(), 6)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "c2", "a3"}, urlsOf(news))
+}
+
+func TestMultiSourcePerSource(t *testing.T) {
+	m := &MultiSource{Fetchers: []Fetcher{listSource{"a", 10}, listSource{"b", 10}}, PerSource: 2}
+	news, err := m.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a1", "b1", "a2", "b2"}, urlsOf(news))
+}
+
+func TestMultiSourceProportional(t *testing.T) {
+	m := &MultiSource{Fetchers: []Fetcher{listSource{"a", 10}, listSource{"b", 10}}, Mix: MixProportional}
+	news, err := m.Fetch(context.Background(), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a1", "a2", "a3", "b1", "b2"}, urlsOf(news))
+
+	m.Mix = "random"
+	_, err = m.Fetch(context.Background(), 5)
+	assert.NotNil(t, err)
+}
+
+func TestMultiSourceWeights(t *testing.T) {
+	m := &MultiSource{Fetchers: []Fetcher{listSource{"a", 10}, listSource{"b", 10}}, Weights: []float64{75, 25}}
+	news, err := m.Fetch(context.Background(), 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a1", "a2", "a3", "b1"}, urlsOf(news))
+}
+
+func TestShares(t *testing.T) {
+	assert.Equal(t, []int{4, 3, 3}, shares(10, equalWeights(3)))
+	assert.Equal(t, []int{6, 3, 1}, shares(10, []float64{60, 30, 10}))
+	assert.Equal(t, []int{1, 0}, shares(1, []float64{2, 1}))
+	assert.Equal(t, []int{0, 0}, shares(5, []float64{0, 0}))
+}
+
+func TestMultiSourceCanonicalDuplicates(t *testing.T) {
+	hn := staticSource{
+		{Title: "Post", URL: "https://example.com/post", CommentsURL: "https://news.ycombinator.com/item?id=1", Source: "hn"},
+		{Title: "Other", URL: "https://example.com/other", CommentsURL: "https://news.ycombinator.com/item?id=2", Source: "hn"},
+	}
+	lobsters := staticSource{
+		{Title: "The post", URL: "http://www.example.com/post/?utm_source=lobsters", CommentsURL: "https://lobste.rs/s/abc", Source: "lobsters"},
+		{Title: "Third", URL: "https://example.com/third", CommentsURL: "https://lobste.rs/s/def", Source: "lobsters"},
+	}
+	m := &MultiSource{Fetchers: []Fetcher{hn, lobsters}}
+	news, err := m.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://example.com/post", "https://example.com/other", "https://example.com/third"}, urlsOf(news))
+}
+
+func TestMultiSourceBatch(t *testing.T) {
+	hn := staticSource{
+		{Title: "Post", URL: "https://example.com/post", Source: "hn"},
+		{Title: "Other", URL: "https://example.com/other", Source: "hn"},
+		{Title: "Last", URL: "https://example.com/last", Source: "hn"},
+	}
+	lobsters := staticSource{
+		{Title: "The post", URL: "http://www.example.com/post/", Source: "lobsters"},
+		{Title: "Third", URL: "https://example.com/third", Source: "lobsters"},
+		{Title: "Fourth", URL: "https://example.com/fourth", Source: "lobsters"},
+	}
+	// lobsters answers once hn's batch is sent
+	sent := make(chan struct{})
+	var batches [][]string
+	m := &MultiSource{Fetchers: []Fetcher{hn, gatedSource{lobsters, sent}}, Batch: func(news []Story) {
+		batches = append(batches, urlsOf(news))
+		if len(batches) == 1 {
+			close(sent)
+		}
+	}}
+	news, err := m.Fetch(context.Background(), 4)
+	assert.Nil(t, err)
+	assert.Len(t, news, 4)
+
+	// each source's share, without the articles of the other batch
+	assert.Equal(t, [][]string{
+		{"https://example.com/post", "https://example.com/other"},
+		{"https://example.com/third", "https://example.com/fourth"},
+	}, batches)
+}
@@ -0,0 +1,133 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NewsAPIURL is the base of the NewsAPI.org API
+const NewsAPIURL = "https://newsapi.org/v2"
+
+func init() {
+	Register(Source{
+		Name:         "newsapi",
+		Description:  "Headlines or searches across news outlets with NewsAPI.org",
+		Flags:        []string{"query", "category", "hl"},
+		Credentials:  "newsapi key (required)",
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &NewsAPISource{
+				Client:     opts.Client,
+				Key:        opts.NewsAPIKey,
+				Query:      opts.Query,
+				Categories: opts.Categories,
+				Locale:     opts.Locale,
+			}
+		},
+	})
+}
+
+// newsAPIResponse decodes the articles endpoints
+type newsAPIResponse struct {
+	Articles []struct {
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+		Author      string `json:"author"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		PublishedAt string `json:"publishedAt"`
+	} `json:"articles"`
+}
+
+// NewsAPISource fetches top headlines or the results of a keyword search
+// from https://newsapi.org/, which needs an API key
+type NewsAPISource struct {
+	Client *http.Client
+	// Key is the NewsAPI.org API key
+	Key string
+	// Query searches every article for these keywords instead of reading
+	// the top headlines
+	Query string
+	// Categories of the top headlines, such as technology or science
+	Categories []string
+	// Locale picks the country of the top headlines, like en-US
+	Locale string
+}
+
+// Fetch gets the articles. With several categories, their headlines are
+// interleaved.
+func (l *NewsAPISource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.Key == "" {
+		return nil, errors.New("the newsapi source needs an API key in the configuration")
+	}
+
+	if len(l.Categories) == 0 {
+		return l.fetchArticles(ctx, "", count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Categories)-1, len(l.Categories), func(ctx context.Context, i int) ([]Story, error) {
+		return l.fetchArticles(ctx, l.Categories[i], count)
+	})
+	return interleave(lists, count), err
+}
+
+// fetchArticles gets up to count articles of a category. A query without
+// category searches everything, otherwise the top headlines are read.
+func (l *NewsAPISource) fetchArticles(ctx context.Context, category string, count int) ([]Story, error) {
+	// a page holds at most 100 articles
+	if count > 100 {
+		count = 100
+	}
+
+	v := url.Values{}
+	v.Set("pageSize", strconv.Itoa(count))
+	endpoint := "/top-headlines"
+	if l.Query != "" {
+		v.Set("q", l.Query)
+	}
+	if l.Query != "" && category == "" {
+		endpoint = "/everything"
+		v.Set("sortBy", "publishedAt")
+	} else {
+		v.Set("country", newsAPICountry(l.Locale))
+		if category != "" {
+			v.Set("category", category)
+		}
+	}
+
+	// the key goes in a header to keep it out of error messages
+	header := http.Header{}
+	header.Set("X-Api-Key", l.Key)
+
+	var doc newsAPIResponse
+	if err := getJSONWithHeader(ctx, l.Client, NewsAPIURL+endpoint+"?"+v.Encode(), header, &doc); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, article := range doc.Articles {
+		news = append(news, Story{
+			Title:       strings.TrimSpace(article.Title),
+			URL:         article.URL,
+			Source:      "newsapi",
+			PublishedAt: parseTime(article.PublishedAt),
+		})
+	}
+
+	return news, nil
+}
+
+// newsAPICountry returns the lower case country of a locale such as en-GB,
+// the US when there is none
+func newsAPICountry(locale string) string {
+	parts := strings.SplitN(locale, "-", 2)
+	if len(parts) < 2 || parts[1] == "" {
+		return "us"
+	}
+	return strings.ToLower(parts[1])
+}
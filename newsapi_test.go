@@ -0,0 +1,40 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewsAPIFetch(t *testing.T) {
+	var queries []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		w.Write([]byte(`{"status": "ok", "articles": [
+			{"source": {"name": "Example"}, "author": "Alice", "title": " One ", "url": "https://example.com/one", "publishedAt": "2018-10-02T15:04:05Z"}
+		]}`))
+	}))
+	defer done()
+
+	news, err := (&NewsAPISource{Client: client, Key: "secret", Categories: []string{"technology"}, Locale: "en-GB"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 1) {
+		assert.Equal(t, "One", news[0].Title)
+		assert.Equal(t, "https://example.com/one", news[0].URL)
+		assert.Equal(t, "newsapi", news[0].Source)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+	}
+
+	_, err = (&NewsAPISource{Client: client, Key: "secret", Query: "golang"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"/v2/top-headlines?category=technology&country=gb&pageSize=10",
+		"/v2/everything?pageSize=10&q=golang&sortBy=publishedAt",
+	}, queries)
+
+	_, err = (&NewsAPISource{Client: client}).Fetch(context.Background(), 10)
+	assert.NotNil(t, err)
+}
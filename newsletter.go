@@ -0,0 +1,143 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Newsletter feeds
+const (
+	ThisWeekInRustURL = "https://this-week-in-rust.org/atom.xml"
+	GolangWeeklyURL   = "https://golangweekly.com/rss/"
+)
+
+func init() {
+	Register(Source{
+		Name:        "twir",
+		Description: "Articles linked from This Week in Rust",
+		New: func(opts Options) Fetcher {
+			return &ThisWeekInRustSource{Client: opts.Client}
+		},
+	})
+	Register(Source{
+		Name:        "golangweekly",
+		Description: "Articles linked from Golang Weekly",
+		New: func(opts Options) Fetcher {
+			return &GolangWeeklySource{Client: opts.Client}
+		},
+	})
+}
+
+// ThisWeekInRustSource opens the articles of the latest This Week in Rust
+// issues, https://this-week-in-rust.org/
+type ThisWeekInRustSource struct {
+	Client *http.Client
+}
+
+// Fetch gets the articles linked from the newest issues
+func (l *ThisWeekInRustSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	n := newsletter{source: "twir", feed: ThisWeekInRustURL, links: "article li a", external: true}
+	return n.fetch(ctx, l.Client, count)
+}
+
+// GolangWeeklySource opens the articles of the latest Golang Weekly issues,
+// https://golangweekly.com/
+type GolangWeeklySource struct {
+	Client *http.Client
+}
+
+// Fetch gets the articles linked from the newest issues
+func (l *GolangWeeklySource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	n := newsletter{source: "golangweekly", feed: GolangWeeklyURL, links: ".mainlink a"}
+	return n.fetch(ctx, l.Client, count)
+}
+
+// newsletter reads the links of the issues of a newsletter rather than the
+// issues themselves
+type newsletter struct {
+	source string
+	// feed lists the issues, newest first
+	feed string
+	// links selects the article links of an issue page
+	links string
+	// external drops the links back to the newsletter's own site
+	external bool
+}
+
+// fetch walks the issues from the newest until it has count articles
+func (n newsletter) fetch(ctx context.Context, client *http.Client, count int) ([]Story, error) {
+	// a handful of issues is plenty, each links to a dozen articles or more
+	issues, err := fetchFeed(ctx, client, n.feed, n.source, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var news []Story
+	for _, issue := range issues {
+		if len(news) >= count {
+			break
+		}
+
+		stories, err := n.fetchIssue(ctx, client, issue.URL)
+		if err != nil {
+			return news, err
+		}
+		for _, story := range stories {
+			if !seen[story.URL] {
+				seen[story.URL] = true
+				news = append(news, story)
+			}
+		}
+	}
+
+	return truncate(news, count), nil
+}
+
+// fetchIssue scrapes the article links of a single issue
+func (n newsletter) fetchIssue(ctx context.Context, client *http.Client, issue string) ([]Story, error) {
+	base, err := url.Parse(issue)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := get(ctx, client, issue)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find(n.links).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		link, err := base.Parse(href)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+			return
+		}
+		if n.external && link.Host == base.Host {
+			return
+		}
+
+		title := strings.TrimSpace(s.Text())
+		if title == "" {
+			return
+		}
+		news = append(news, Story{
+			Title:  title,
+			URL:    link.String(),
+			Source: n.source,
+		})
+	})
+
+	return news, nil
+}
@@ -0,0 +1,66 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const twirFeed = `<feed xmlns="http://www.w3.org/2005/Atom">
+<entry><title>This Week in Rust 254</title><link href="https://this-week-in-rust.org/blog/254/"/></entry>
+<entry><title>This Week in Rust 253</title><link href="https://this-week-in-rust.org/blog/253/"/></entry>
+</feed>`
+
+func TestThisWeekInRustFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/atom.xml":
+			w.Write([]byte(twirFeed))
+		case "/blog/254/":
+			w.Write([]byte(`<article><ul>
+<li><a href="https://example.com/async">Async in depth</a></li>
+<li><a href="/blog/253/">Last week</a></li>
+<li><a href="mailto:editors@example.com">Mail us</a></li>
+</ul></article>`))
+		case "/blog/253/":
+			w.Write([]byte(`<article><ul>
+<li><a href="https://example.com/async">Async in depth</a></li>
+<li><a href="https://example.com/traits">Traits</a></li>
+</ul></article>`))
+		}
+	}))
+	defer done()
+
+	news, err := (&ThisWeekInRustSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "Async in depth", news[0].Title)
+		assert.Equal(t, "https://example.com/async", news[0].URL)
+		assert.Equal(t, "twir", news[0].Source)
+		assert.Equal(t, "https://example.com/traits", news[1].URL)
+	}
+}
+
+func TestGolangWeeklyFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rss/":
+			w.Write([]byte(`<rss><channel><item><title>Issue 230</title><link>https://golangweekly.com/issues/230</link></item></channel></rss>`))
+		case "/issues/230":
+			w.Write([]byte(`<table>
+<tr><td><span class="mainlink"><a href="https://golangweekly.com/link/1/web">Go 1.11 is released</a></span></td></tr>
+<tr><td><a href="https://golangweekly.com/issues">Archives</a></td></tr>
+</table>`))
+		}
+	}))
+	defer done()
+
+	news, err := (&GolangWeeklySource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 1) {
+		assert.Equal(t, "Go 1.11 is released", news[0].Title)
+		assert.Equal(t, "https://golangweekly.com/link/1/web", news[0].URL)
+	}
+}
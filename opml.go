@@ -0,0 +1,90 @@
+package hnreader
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+)
+
+func init() {
+	Register(Source{
+		Name:         "opml",
+		Description:  "Every feed of an OPML file given with --opml",
+		Flags:        []string{"opml"},
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &OPMLSource{Client: opts.Client, Workers: opts.Workers, Path: opts.OPML}
+		},
+	})
+}
+
+// OPMLSource reads every feed listed in an OPML file, such as the exports of
+// Feedly or NewsBlur, and interleaves their stories
+type OPMLSource struct {
+	Client *http.Client
+	// Workers is the number of feeds fetched at once
+	Workers int
+	// Path of the OPML file
+	Path string
+}
+
+// opmlOutline is an outline of an OPML document. Feeds have an xmlUrl,
+// folders only hold more outlines.
+type opmlOutline struct {
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// Fetch gets the newest stories of every feed, taking one from each in turn
+func (l *OPMLSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.Path == "" {
+		return nil, errors.New("the opml source needs an OPML file")
+	}
+
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	feeds, err := parseOPML(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(feeds) == 0 {
+		return nil, errors.New(l.Path + ": no feeds found")
+	}
+
+	lists, err := fetchLists(ctx, 0, len(feeds)-1, l.Workers, func(ctx context.Context, i int) ([]Story, error) {
+		return fetchFeed(ctx, l.Client, feeds[i], "opml", count)
+	})
+	return interleave(lists, count), err
+}
+
+// parseOPML returns the feed URLs of an OPML document, including those in
+// folders
+func parseOPML(r io.Reader) ([]string, error) {
+	doc := struct {
+		Outlines []opmlOutline `xml:"body>outline"`
+	}{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var feeds []string
+	var walk func(outlines []opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" {
+				feeds = append(feeds, outline.XMLURL)
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(doc.Outlines)
+
+	return feeds, nil
+}
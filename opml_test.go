@@ -0,0 +1,55 @@
+package hnreader
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const opmlDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0"><head><title>Subscriptions</title></head><body>
+<outline text="Go" type="rss" xmlUrl="https://example.com/go.xml"/>
+<outline text="Folder">
+	<outline text="Rust" type="rss" xmlUrl="https://example.com/rust.xml"/>
+</outline>
+</body></opml>`
+
+func TestParseOPML(t *testing.T) {
+	feeds, err := parseOPML(strings.NewReader(opmlDoc))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://example.com/go.xml", "https://example.com/rust.xml"}, feeds)
+}
+
+func TestOPMLSourceFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".xml")
+		w.Write([]byte(`<rss><channel>
+<item><title>` + name + ` 1</title><link>https://example.com/` + name + `/1</link></item>
+<item><title>` + name + ` 2</title><link>https://example.com/` + name + `/2</link></item>
+</channel></rss>`))
+	}))
+	defer done()
+
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "feeds.opml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(opmlDoc), 0644))
+
+	news, err := (&OPMLSource{Client: client, Path: path}).Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "go 1", news[0].Title)
+		assert.Equal(t, "rust 1", news[1].Title)
+		assert.Equal(t, "go 2", news[2].Title)
+	}
+
+	_, err = (&OPMLSource{Client: client, Path: filepath.Join(dir, "missing.opml")}).Fetch(context.Background(), 3)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,160 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultWorkers is how many pages a paginated source fetches at once
+// unless told otherwise.
+const DefaultWorkers = 4
+
+// pageFunc fetches the stories on a single page
+type pageFunc func(ctx context.Context, page int) ([]Story, error)
+
+// fetchPages calls fetch for every page from first to last using at most
+// workers goroutines and returns the stories in page order. Pages that fail
+// are skipped and their errors returned joined together. When ctx is done
+// the pages fetched so far are returned together with ctx.Err().
+func fetchPages(ctx context.Context, first, last, workers int, fetch pageFunc) ([]Story, error) {
+	results, err := fetchLists(ctx, first, last, workers, fetch)
+
+	var news []Story
+	for _, stories := range results {
+		news = append(news, stories...)
+	}
+	return news, err
+}
+
+// fetchLists works like fetchPages but keeps the stories of each page apart,
+// leaving failed pages empty
+func fetchLists(ctx context.Context, first, last, workers int, fetch pageFunc) ([][]Story, error) {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	results := make([][]Story, last-first+1)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for w := 0; w < workers && w < len(results); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range jobs {
+				stories, err := fetch(ctx, page)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					continue
+				}
+				results[page-first] = stories
+			}
+		}()
+	}
+
+feed:
+	for page := first; page <= last; page++ {
+		select {
+		case jobs <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	return results, errors.Join(errs...)
+}
+
+// pager walks the numbered pages of a listing, starting at page one, and
+// collects unique stories until it has enough of them
+type pager struct {
+	// perPage is the number of stories expected on a full page
+	perPage int
+	// workers is the number of pages fetched at once
+	workers int
+	fetch   pageFunc
+}
+
+// collect returns exactly count stories, or fewer when the listing runs out.
+// Stories that moved to a later page while we were paging are skipped and
+// more pages are fetched to make up for them. Pages that fail are skipped
+// too and their errors returned with the stories.
+func (p pager) collect(ctx context.Context, count int) ([]Story, error) {
+	seen := make(map[string]bool)
+	var news []Story
+	var errs []error
+
+	for page := 1; len(news) < count; {
+		missing := count - len(news)
+		last := page + (missing+p.perPage-1)/p.perPage - 1
+
+		batch, err := fetchPages(ctx, page, last, p.workers, p.fetch)
+		added := 0
+		for _, story := range batch {
+			if seen[story.key()] {
+				continue
+			}
+			seen[story.key()] = true
+			news = append(news, story)
+			added++
+		}
+
+		if ctx.Err() != nil {
+			return truncate(news, count), err
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// nothing new means we ran out of pages
+		if added == 0 {
+			break
+		}
+		page = last + 1
+	}
+
+	return truncate(news, count), errors.Join(errs...)
+}
+
+// truncate returns at most the first count stories
+func truncate(news []Story, count int) []Story {
+	if len(news) > count {
+		return news[:count]
+	}
+	return news
+}
+
+// interleave takes one story from each list in turn, skipping the ones
+// linking to an article already taken, until it has count stories or every
+// list is used up
+func interleave(lists [][]Story, count int) []Story {
+	seen := make(map[string]bool)
+	var news []Story
+
+	for i := 0; len(news) < count; i++ {
+		more := false
+		for _, stories := range lists {
+			if i >= len(stories) {
+				continue
+			}
+			more = true
+			if story := stories[i]; !seen[story.articleKey()] && len(news) < count {
+				seen[story.articleKey()] = true
+				news = append(news, story)
+			}
+		}
+		if !more {
+			break
+		}
+	}
+
+	return news
+}
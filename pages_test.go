@@ -0,0 +1,111 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchPagesKeepsOrder(t *testing.T) {
+	var running, peak int32
+	news, err := fetchPages(context.Background(), 1, 8, 3, func(ctx context.Context, page int) ([]Story, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&running, -1)
+
+		// later pages finish first
+		time.Sleep(time.Duration(10-page) * time.Millisecond)
+		if page == 4 {
+			return nil, errors.New("page 4 is broken")
+		}
+		return []Story{{Title: strconv.Itoa(page)}}, nil
+	})
+
+	assert.EqualError(t, err, "page 4 is broken")
+	var titles []string
+	for _, story := range news {
+		titles = append(titles, story.Title)
+	}
+	assert.Equal(t, []string{"1", "2", "3", "5", "6", "7", "8"}, titles)
+	assert.True(t, peak <= 3)
+}
+
+func TestFetchPagesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	news, err := fetchPages(ctx, 1, 100, 1, func(ctx context.Context, page int) ([]Story, error) {
+		if page == 2 {
+			cancel()
+			return nil, ctx.Err()
+		}
+		return []Story{{Title: strconv.Itoa(page)}}, nil
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, news, 1)
+}
+
+// listingPage simulates a listing of total stories, size per page, where
+// shift stories moved down a page between requests
+func listingPage(total, size, shift int) pageFunc {
+	return func(ctx context.Context, page int) ([]Story, error) {
+		var stories []Story
+		start := (page-1)*size - shift
+		if page == 1 {
+			start = 0
+		}
+		for i := start; i < page*size-shift && i < total; i++ {
+			stories = append(stories, Story{URL: "https://example.com/" + strconv.Itoa(i)})
+		}
+		return stories, nil
+	}
+}
+
+func TestPagerCollect(t *testing.T) {
+	p := pager{perPage: 30, workers: 2, fetch: listingPage(500, 30, 0)}
+	news, err := p.collect(context.Background(), 45)
+	assert.Nil(t, err)
+	assert.Len(t, news, 45)
+	assert.Equal(t, "https://example.com/44", news[44].URL)
+}
+
+func TestPagerCollectSkipsDuplicates(t *testing.T) {
+	p := pager{perPage: 10, workers: 2, fetch: listingPage(500, 10, 3)}
+	news, err := p.collect(context.Background(), 20)
+	assert.Nil(t, err)
+	assert.Len(t, news, 20)
+
+	seen := make(map[string]bool)
+	for _, story := range news {
+		assert.False(t, seen[story.URL], story.URL)
+		seen[story.URL] = true
+	}
+}
+
+func TestPagerCollectRunsOut(t *testing.T) {
+	p := pager{perPage: 10, workers: 2, fetch: listingPage(15, 10, 0)}
+	news, err := p.collect(context.Background(), 40)
+	assert.Nil(t, err)
+	assert.Len(t, news, 15)
+}
+
+func TestInterleave(t *testing.T) {
+	a := []Story{{URL: "a1"}, {URL: "a2"}, {URL: "a3"}}
+	b := []Story{{URL: "b1"}, {URL: "a2"}}
+
+	var urls []string
+	for _, story := range interleave([][]Story{a, b, nil}, 10) {
+		urls = append(urls, story.URL)
+	}
+	assert.Equal(t, []string{"a1", "b1", "a2", "a3"}, urls)
+	assert.Len(t, interleave([][]Story{a, b}, 3), 3)
+}
@@ -0,0 +1,83 @@
+package hnreader
+
+import (
+	"strings"
+)
+
+// PaywallTag is the tag MarkPaywalled gives the stories behind a paywall
+const PaywallTag = "paywalled"
+
+// PaywalledDomains are the sites known to keep most of their articles behind
+// a paywall or a tight metered one.
+var PaywalledDomains = []string{
+	"barrons.com",
+	"bloomberg.com",
+	"economist.com",
+	"ft.com",
+	"hbr.org",
+	"latimes.com",
+	"nytimes.com",
+	"newyorker.com",
+	"theathletic.com",
+	"theatlantic.com",
+	"theinformation.com",
+	"thetimes.co.uk",
+	"telegraph.co.uk",
+	"washingtonpost.com",
+	"wired.com",
+	"wsj.com",
+}
+
+// paywallMarkers are put in titles by submitters, and by LWN for its
+// subscriber-only articles, to warn about a paywall
+var paywallMarkers = []string{"[paywall]", "(paywall)", "[paywalled]", "(paywalled)", "[$]"}
+
+// OnDomain tells whether the story links to domain or one of its subdomains.
+func (s Story) OnDomain(domain string) bool {
+	host, domain := s.Domain(), strings.ToLower(strings.TrimPrefix(domain, "www."))
+	return host != "" && (host == domain || strings.HasSuffix(host, "."+domain))
+}
+
+// Paywalled tells whether the story is likely behind a paywall: it links to
+// one of PaywalledDomains or domains, or is marked as such by its title or
+// tags.
+func (s Story) Paywalled(domains []string) bool {
+	for _, list := range [][]string{PaywalledDomains, domains} {
+		for _, domain := range list {
+			if s.OnDomain(domain) {
+				return true
+			}
+		}
+	}
+	title := strings.ToLower(s.Title)
+	for _, marker := range paywallMarkers {
+		if strings.Contains(title, marker) {
+			return true
+		}
+	}
+	for _, tag := range s.Tags {
+		if strings.EqualFold(tag, "paywall") || strings.EqualFold(tag, PaywallTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkPaywalled adds PaywallTag to the tags of the stories likely behind a
+// paywall, as told by Paywalled with the extra domains.
+func MarkPaywalled(news []Story, domains []string) {
+	marked := Tagged([]string{PaywallTag})
+	for i, story := range news {
+		if story.Paywalled(domains) && !marked(story) {
+			news[i].Tags = append(story.Tags, PaywallTag)
+		}
+	}
+}
+
+// SkipPaywalled leaves out the stories likely behind a paywall, as told by
+// Paywalled with the extra domains.
+func SkipPaywalled(domains []string) Filter {
+	return func(story Story) bool {
+		return !story.Paywalled(domains)
+	}
+}
@@ -0,0 +1,39 @@
+package hnreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoryOnDomain(t *testing.T) {
+	story := Story{URL: "https://www.blog.example.com/post"}
+	assert.True(t, story.OnDomain("example.com"))
+	assert.True(t, story.OnDomain("www.blog.example.com"))
+	assert.False(t, story.OnDomain("ample.com"))
+	assert.False(t, Story{}.OnDomain("example.com"))
+}
+
+func TestStoryPaywalled(t *testing.T) {
+	assert.True(t, Story{URL: "https://www.nytimes.com/2024/01/01/tech.html"}.Paywalled(nil))
+	assert.True(t, Story{URL: "https://cooking.nytimes.com/recipe"}.Paywalled(nil))
+	assert.True(t, Story{URL: "https://lwn.net/Articles/1/", Title: "[$] The kernel"}.Paywalled(nil))
+	assert.True(t, Story{URL: "https://example.com", Title: "Some news (Paywall)"}.Paywalled(nil))
+	assert.True(t, Story{URL: "https://example.com", Tags: []string{"Paywall"}}.Paywalled(nil))
+	assert.True(t, Story{URL: "https://news.example.com"}.Paywalled([]string{"example.com"}))
+	assert.False(t, Story{URL: "https://example.com", Title: "Free as in beer"}.Paywalled(nil))
+}
+
+func TestMarkPaywalled(t *testing.T) {
+	news := []Story{
+		{URL: "https://www.ft.com/content/1", Tags: []string{"markets"}},
+		{URL: "https://example.com"},
+		{URL: "https://www.wsj.com/1", Tags: []string{PaywallTag}},
+	}
+	MarkPaywalled(news, nil)
+	assert.Equal(t, []string{"markets", PaywallTag}, news[0].Tags)
+	assert.Nil(t, news[1].Tags)
+	assert.Equal(t, []string{PaywallTag}, news[2].Tags)
+
+	assert.Equal(t, []Story{news[1]}, SkipPaywalled(nil).Apply(news))
+}
@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// PhoronixURL is the Phoronix news feed
+const PhoronixURL = "https://www.phoronix.com/rss.php"
+
+func init() {
+	Register(Source{
+		Name:        "phoronix",
+		Description: "Linux hardware and benchmark news from phoronix.com",
+		New: func(opts Options) Fetcher {
+			return &PhoronixSource{Client: opts.Client}
+		},
+	})
+}
+
+// PhoronixSource fetches latest stories from https://www.phoronix.com/
+type PhoronixSource struct {
+	Client *http.Client
+}
+
+// Fetch gets news from Phoronix
+func (l *PhoronixSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return fetchFeed(ctx, l.Client, PhoronixURL, "phoronix", count)
+}
@@ -0,0 +1,56 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// PinboardFeedURL is where the public Pinboard feeds live
+const PinboardFeedURL = "https://feeds.pinboard.in/rss/"
+
+// pinboardModes maps the listing modes to their feeds
+var pinboardModes = map[string]string{
+	"popular": "popular/",
+	"recent":  "recent/",
+}
+
+func init() {
+	Register(Source{
+		Name:        "pinboard",
+		Description: "Popular or recent bookmarks from pinboard.in, optionally by tag",
+		Flags:       []string{"mode", "tags"},
+		New: func(opts Options) Fetcher {
+			return &PinboardSource{Client: opts.Client, Mode: opts.Mode, Tags: opts.Tags}
+		},
+	})
+}
+
+// PinboardSource fetches the bookmarks of https://pinboard.in/popular/ or
+// https://pinboard.in/recent/
+type PinboardSource struct {
+	Client *http.Client
+	// Mode selects the listing: popular (default) or recent
+	Mode string
+	// Tags reads the recent bookmarks with these tags instead, as there is
+	// no popular listing per tag
+	Tags []string
+}
+
+// Fetch gets the bookmarks. With tags, the recent bookmarks of every tag
+// are interleaved.
+func (l *PinboardSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	listing, err := lookupMode("pinboard mode", l.Mode, "popular", pinboardModes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.Tags) == 0 {
+		return fetchFeed(ctx, l.Client, PinboardFeedURL+listing, "pinboard", count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Tags)-1, len(l.Tags), func(ctx context.Context, i int) ([]Story, error) {
+		return fetchFeed(ctx, l.Client, PinboardFeedURL+"t:"+url.PathEscape(l.Tags[i])+"/", "pinboard", count)
+	})
+	return interleave(lists, count), err
+}
@@ -0,0 +1,98 @@
+package hnreader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ExecPluginPrefix starts the name of the executables providing sources
+const ExecPluginPrefix = "hnreader-source-"
+
+// RegisterExecPlugins registers a source for every executable on $PATH named
+// hnreader-source-<name>, like "hnreader-source-hackaday". Names that are
+// taken already, by another source or an earlier $PATH entry, are skipped.
+func RegisterExecPlugins() {
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			name := execPluginName(file)
+			if name == "" || isRegistered(name) {
+				continue
+			}
+
+			path := filepath.Join(dir, file.Name())
+			Register(Source{
+				Name:        name,
+				Description: "Plugin " + path,
+				New: func(opts Options) Fetcher {
+					return &ExecSource{Name: name, Path: path}
+				},
+			})
+		}
+	}
+}
+
+// execPluginName returns the source name of a plugin executable, or "" when
+// file isn't one
+func execPluginName(file os.FileInfo) string {
+	name := file.Name()
+	if !strings.HasPrefix(name, ExecPluginPrefix) || file.IsDir() {
+		return ""
+	}
+
+	if runtime.GOOS == OSWindows {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if file.Mode()&0111 == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(name, ExecPluginPrefix)
+}
+
+// ExecSource runs an external program to fetch stories. The program gets the
+// number of stories wanted as its only argument and prints a JSON array of
+// stories, in the JSON form of Story, to its standard output.
+type ExecSource struct {
+	// Name of the source, set on stories that don't have one
+	Name string
+	// Path of the executable
+	Path string
+}
+
+// Fetch runs the plugin and decodes its stories
+func (l *ExecSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, l.Path, strconv.Itoa(count))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%s: %v", l.Path, err)
+	}
+
+	var news []Story
+	if err := json.Unmarshal(stdout.Bytes(), &news); err != nil {
+		return nil, fmt.Errorf("%s: %v", l.Path, err)
+	}
+	for i := range news {
+		if news[i].Source == "" {
+			news[i].Source = l.Name
+		}
+	}
+	return truncate(news, count), nil
+}
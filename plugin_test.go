@@ -0,0 +1,58 @@
+package hnreader
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const execPlugin = `#!/bin/sh
+echo '[{"title": "Asked for '$1'", "url": "https://example.com/1", "score": 3, "published_at": "2018-10-02T15:04:05Z"},
+{"title": "Two", "url": "https://example.com/2", "source": "other"},
+{"title": "Three", "url": "https://example.com/3"}]'
+`
+
+func TestExecPlugins(t *testing.T) {
+	if runtime.GOOS == OSWindows {
+		t.Skip("plugins are shell scripts here")
+	}
+
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "hnreader-source-test-exec"), []byte(execPlugin), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "hnreader-source-test-noexec"), []byte(execPlugin), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "hnreader-source-test-broken"), []byte("#!/bin/sh\necho nope\n"), 0755))
+
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", dir)
+
+	RegisterExecPlugins()
+	names := SourceNames()
+	assert.Contains(t, names, "test-exec")
+	assert.NotContains(t, names, "test-noexec")
+
+	src, err := NewFetcher("test-exec", Options{})
+	assert.Nil(t, err)
+	news, err := src.Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 2) {
+		assert.Equal(t, "Asked for 2", news[0].Title)
+		assert.Equal(t, "https://example.com/1", news[0].URL)
+		assert.Equal(t, 3, news[0].Score)
+		assert.Equal(t, "test-exec", news[0].Source)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+		assert.Equal(t, "other", news[1].Source)
+	}
+
+	src, err = NewFetcher("test-broken", Options{})
+	assert.Nil(t, err)
+	_, err = src.Fetch(context.Background(), 2)
+	assert.NotNil(t, err)
+}
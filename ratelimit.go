@@ -0,0 +1,118 @@
+package hnreader
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Rate limit defaults, per host
+const (
+	DefaultRate  = 2.0
+	DefaultBurst = 4
+)
+
+// DefaultHostRates lifts the limit for APIs that are meant to be queried
+// once per item.
+var DefaultHostRates = map[string]float64{
+	"hacker-news.firebaseio.com": 0,
+}
+
+// RateLimitTransport throttles requests with a token bucket per host, so
+// scraping many pages doesn't hammer a single site.
+type RateLimitTransport struct {
+	// Rate is the sustained number of requests per second for each host
+	Rate float64
+	// Burst is how many requests may be sent at once before throttling
+	Burst int
+	// HostRates overrides Rate for individual hosts, 0 meaning no limit
+	HostRates map[string]float64
+	// Transport makes the actual requests, http.DefaultTransport when nil
+	Transport http.RoundTripper
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket holds the tokens left for one host
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if t.rate(req.URL.Host) > 0 {
+		delay := t.reserve(req.URL.Host)
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				timer.Stop()
+				t.cancel(req.URL.Host)
+				return nil, req.Context().Err()
+			}
+		}
+	}
+
+	return transport.RoundTrip(req)
+}
+
+// rate returns the requests per second allowed for host
+func (t *RateLimitTransport) rate(host string) float64 {
+	if rate, ok := t.HostRates[host]; ok {
+		return rate
+	}
+	return t.Rate
+}
+
+// reserve takes a token for host and returns how long to wait until it is
+// actually available
+func (t *RateLimitTransport) reserve(host string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	burst := float64(t.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	now := time.Now()
+	if t.buckets == nil {
+		t.buckets = make(map[string]*bucket)
+	}
+	b, ok := t.buckets[host]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		t.buckets[host] = b
+	}
+
+	rate := t.rate(host)
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
+
+// cancel returns a reserved but unused token for host
+func (t *RateLimitTransport) cancel(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if b, ok := t.buckets[host]; ok {
+		b.tokens++
+	}
+}
@@ -0,0 +1,55 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitReserve(t *testing.T) {
+	transport := &RateLimitTransport{Rate: 10, Burst: 2}
+
+	assert.Equal(t, time.Duration(0), transport.reserve("a.com"))
+	assert.Equal(t, time.Duration(0), transport.reserve("a.com"))
+
+	// the bucket for a.com is empty, b.com is untouched
+	delay := transport.reserve("a.com")
+	assert.True(t, delay > 50*time.Millisecond && delay <= 100*time.Millisecond, delay.String())
+	assert.Equal(t, time.Duration(0), transport.reserve("b.com"))
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RateLimitTransport{Rate: 20, Burst: 1}}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+}
+
+func TestRateLimitTransportCancelled(t *testing.T) {
+	transport := &RateLimitTransport{Rate: 0.01, Burst: 1}
+	transport.reserve("example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err := transport.RoundTrip(req.WithContext(ctx))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRateLimitHostRates(t *testing.T) {
+	transport := &RateLimitTransport{Rate: 1, Burst: 1, HostRates: map[string]float64{"api.example.com": 0}}
+	assert.Equal(t, 0.0, transport.rate("api.example.com"))
+	assert.Equal(t, 1.0, transport.rate("example.com"))
+}
@@ -0,0 +1,65 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WordsPerMinute is the reading speed reading times are estimated with
+const WordsPerMinute = 230
+
+// Words returns the number of words of the article.
+func (a Article) Words() int {
+	n := 0
+	for _, paragraph := range a.Paragraphs {
+		n += len(strings.Fields(paragraph))
+	}
+	return n
+}
+
+// ReadingMinutes estimates how many minutes the article takes to read,
+// rounded up. It is zero only for articles without any text.
+func (a Article) ReadingMinutes() int {
+	return (a.Words() + WordsPerMinute - 1) / WordsPerMinute
+}
+
+// EstimateReadingTimes fetches the article of each story, at most workers at
+// a time, and sets its ReadingMinutes. Stories whose article can't be fetched
+// or has no text, and those linking to their own discussion, are left as
+// they are.
+func EstimateReadingTimes(ctx context.Context, client *http.Client, news []Story, workers int) {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(news); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				article, err := FetchArticle(ctx, client, news[i].URL)
+				if err == nil {
+					news[i].ReadingMinutes = article.ReadingMinutes()
+				}
+			}
+		}()
+	}
+
+feed:
+	for i, story := range news {
+		if story.URL == "" || story.URL == story.CommentsURL {
+			continue
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
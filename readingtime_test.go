@@ -0,0 +1,47 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleReadingMinutes(t *testing.T) {
+	assert.Equal(t, 0, Article{}.ReadingMinutes())
+
+	article := Article{Paragraphs: []string{"one two  three", "four"}}
+	assert.Equal(t, 4, article.Words())
+	assert.Equal(t, 1, article.ReadingMinutes())
+
+	article.Paragraphs = []string{strings.Repeat("word ", WordsPerMinute*2+1)}
+	assert.Equal(t, 3, article.ReadingMinutes())
+}
+
+func TestEstimateReadingTimes(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/long":
+			w.Write([]byte("<main><p>" + strings.Repeat("word ", WordsPerMinute*5) + "</p></main>"))
+		case "/short":
+			w.Write([]byte("<main><p>A few words.</p></main>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer done()
+
+	news := []Story{
+		{URL: "https://example.com/long"},
+		{URL: "https://example.com/short"},
+		{URL: "https://example.com/missing"},
+		{URL: "https://news.ycombinator.com/item?id=1", CommentsURL: "https://news.ycombinator.com/item?id=1"},
+	}
+	EstimateReadingTimes(context.Background(), client, news, 2)
+	assert.Equal(t, 5, news[0].ReadingMinutes)
+	assert.Equal(t, 1, news[1].ReadingMinutes)
+	assert.Equal(t, 0, news[2].ReadingMinutes)
+	assert.Equal(t, 0, news[3].ReadingMinutes)
+}
@@ -0,0 +1,98 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Endpoints adding a page to the read-later services
+const (
+	InstapaperAddURL = "https://www.instapaper.com/api/add"
+	PinboardAddURL   = "https://api.pinboard.in/v1/posts/add"
+)
+
+// ReadLaterConfig is the read_later section of the configuration, naming the
+// service stories are saved to.
+type ReadLaterConfig struct {
+	// Service is "instapaper" or "pinboard"
+	Service string `json:"service"`
+	// Username and Password log into Instapaper
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Token is the Pinboard API token, like "user:0123456789ABCDEF"
+	Token string `json:"token"`
+}
+
+// SaveForLater adds the story to the read-later service of config.
+func SaveForLater(ctx context.Context, client *http.Client, config ReadLaterConfig, story Story) error {
+	switch config.Service {
+	case "instapaper":
+		return saveToInstapaper(ctx, client, config, story)
+	case "pinboard":
+		return saveToPinboard(ctx, client, config, story)
+	case "":
+		return errors.New("no read-later service, set read_later in the configuration")
+	default:
+		return fmt.Errorf("unknown read-later service %q (one of instapaper, pinboard)", config.Service)
+	}
+}
+
+// saveToInstapaper uses the simple API of Instapaper, which only needs the
+// account's credentials
+func saveToInstapaper(ctx context.Context, client *http.Client, config ReadLaterConfig, story Story) error {
+	form := url.Values{}
+	form.Set("url", story.URL)
+	form.Set("title", story.Title)
+
+	req, err := http.NewRequest(http.MethodPost, InstapaperAddURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(config.Username, config.Password)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusForbidden:
+		return errors.New("instapaper: invalid username or password")
+	default:
+		return fmt.Errorf("instapaper: %s", resp.Status)
+	}
+}
+
+// saveToPinboard bookmarks the story on Pinboard, marked as unread
+func saveToPinboard(ctx context.Context, client *http.Client, config ReadLaterConfig, story Story) error {
+	query := url.Values{}
+	query.Set("auth_token", config.Token)
+	query.Set("url", story.URL)
+	query.Set("description", story.Title)
+	query.Set("toread", "yes")
+	query.Set("format", "json")
+
+	var result struct {
+		ResultCode string `json:"result_code"`
+	}
+	if err := getJSON(ctx, client, PinboardAddURL+"?"+query.Encode(), &result); err != nil {
+		// the token is part of the address, keep it out of the error
+		return errors.New("pinboard: " + strings.Replace(err.Error(), url.QueryEscape(config.Token), "...", -1))
+	}
+	if result.ResultCode != "done" {
+		return fmt.Errorf("pinboard: %s", result.ResultCode)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveForLater(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/add":
+			user, password, _ := r.BasicAuth()
+			if user != "me" || password != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			assert.Equal(t, "https://example.com/a", r.FormValue("url"))
+			assert.Equal(t, "A story", r.FormValue("title"))
+			w.WriteHeader(http.StatusCreated)
+		case "/v1/posts/add":
+			assert.Equal(t, "yes", r.FormValue("toread"))
+			if r.FormValue("auth_token") != "me:TOKEN" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"result_code":"done"}`))
+		}
+	}))
+	defer done()
+
+	ctx := context.Background()
+	story := Story{Title: "A story", URL: "https://example.com/a"}
+
+	assert.Nil(t, SaveForLater(ctx, client, ReadLaterConfig{Service: "instapaper", Username: "me", Password: "secret"}, story))
+	assert.EqualError(t, SaveForLater(ctx, client, ReadLaterConfig{Service: "instapaper", Username: "me"}, story),
+		"instapaper: invalid username or password")
+
+	assert.Nil(t, SaveForLater(ctx, client, ReadLaterConfig{Service: "pinboard", Token: "me:TOKEN"}, story))
+	err := SaveForLater(ctx, client, ReadLaterConfig{Service: "pinboard", Token: "me:WRONG"}, story)
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "WRONG")
+
+	assert.NotNil(t, SaveForLater(ctx, client, ReadLaterConfig{}, story))
+	assert.EqualError(t, SaveForLater(ctx, client, ReadLaterConfig{Service: "pocket"}, story),
+		`unknown read-later service "pocket" (one of instapaper, pinboard)`)
+}
@@ -0,0 +1,15 @@
+package hnreader
+
+// IsRead tells whether the story, or its discussion, is among the read
+// URLs returned by History.ReadURLs.
+func IsRead(read map[string]bool, story Story) bool {
+	return read[CanonicalURL(story.URL)] || story.CommentsURL != "" && read[CanonicalURL(story.CommentsURL)]
+}
+
+// Unread leaves out the stories among the read URLs returned by
+// History.ReadURLs, as told by IsRead.
+func Unread(read map[string]bool) Filter {
+	return func(story Story) bool {
+		return !IsRead(read, story)
+	}
+}
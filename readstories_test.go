@@ -0,0 +1,55 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryReadURLs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	h, err := OpenHistory(filepath.Join(dir, "history.db"))
+	assert.Nil(t, err)
+	defer h.Close()
+
+	read, err := h.ReadURLs()
+	assert.Nil(t, err)
+	assert.Empty(t, read)
+
+	now := time.Now()
+	first := Story{Title: "First", URL: "https://example.com/1"}
+	assert.Nil(t, h.RecordFetched([]Story{first, {Title: "Fetched", URL: "https://example.com/3"}}, now))
+	assert.Nil(t, h.RecordOpened([]Story{first}, now))
+	assert.Nil(t, h.MarkRead([]Story{{Title: "Second", URL: "http://www.example.com/2/"}}, now))
+
+	read, err = h.ReadURLs()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"https://example.com/1": true, "https://example.com/2": true}, read)
+
+	// marking a story as read doesn't count as opening it
+	entry, _, err := h.Lookup(Story{URL: "https://example.com/2"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, entry.Opens)
+	assert.False(t, entry.Opened.IsZero())
+}
+
+func TestUnread(t *testing.T) {
+	read := map[string]bool{
+		"https://example.com/1":                  true,
+		"https://news.ycombinator.com/item?id=2": true,
+	}
+	news := []Story{
+		{Title: "Opened", URL: "http://www.example.com/1/?utm_source=hn"},
+		{Title: "Discussion opened", URL: "https://example.com/2", CommentsURL: "https://news.ycombinator.com/item?id=2"},
+		{Title: "New", URL: "https://example.com/3", CommentsURL: "https://news.ycombinator.com/item?id=3"},
+	}
+	assert.True(t, IsRead(read, news[0]))
+	assert.Equal(t, news[2:], Unread(read).Apply(news))
+}
@@ -0,0 +1,235 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jzelinskie/geddit"
+)
+
+// RedditURL is the base of the reddit JSON listings
+const RedditURL = "https://www.reddit.com"
+
+// DefaultSubreddit is read when no subreddit is given
+const DefaultSubreddit = "programming"
+
+// redditMaxLimit is the largest page reddit returns
+const redditMaxLimit = 100
+
+// redditSorts are the listing orders reddit offers
+var redditSorts = map[string]string{
+	"hot":    geddit.HotSubmissions,
+	"new":    geddit.NewSubmissions,
+	"top":    geddit.TopSubmissions,
+	"rising": geddit.RisingSubmissions,
+}
+
+// redditTimes are the time windows of the top listing
+var redditTimes = map[string]string{
+	"hour":  geddit.ThisHour,
+	"day":   geddit.ThisDay,
+	"week":  "week",
+	"month": geddit.ThisMonth,
+	"year":  geddit.ThisYear,
+	"all":   geddit.AllTime,
+}
+
+func init() {
+	Register(Source{
+		Name:        "reddit",
+		Description: "Hot posts from reddit (r/programming unless subreddits or a multireddit are given)",
+		Flags:       []string{"subreddit", "multi", "sort", "time", "mode", "skip-nsfw", "links-only"},
+		Credentials: "reddit login (optional, needed for --mode saved)",
+		New: func(opts Options) Fetcher {
+			return &RedditSource{
+				Client:     opts.Client,
+				Subreddits: opts.Subreddits,
+				Sort:       opts.Sort,
+				Time:       opts.Time,
+				Multi:      opts.Multi,
+				Mode:       opts.Mode,
+				NSFW:       opts.NSFW,
+				LinksOnly:  opts.LinksOnly,
+				Auth:       opts.RedditAuth,
+			}
+		},
+	})
+}
+
+// redditListing decodes the JSON listing returned by reddit
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Data *geddit.Submission
+		}
+		After string
+	}
+}
+
+// RedditSource fetches new stories from one or more subreddits.
+type RedditSource struct {
+	Client *http.Client
+	// Subreddits to read, r/programming when empty. Several subreddits are
+	// merged into a single listing.
+	Subreddits []string
+	// Sort is one of hot (default), new, top or rising
+	Sort string
+	// Time limits the top listing to the last hour, day, week, month,
+	// year or all time
+	Time string
+	// Multi is a multireddit such as "user/m/tech", read instead of
+	// Subreddits
+	Multi string
+	// Mode "saved" reads the saved posts of the logged in account
+	Mode string
+	// NSFW keeps the posts marked over 18, which are skipped otherwise
+	NSFW bool
+	// LinksOnly skips the text posts, which link to their own discussion
+	LinksOnly bool
+	// Auth logs into reddit when set, which raises the rate limit and
+	// gives access to private multireddits and saved posts
+	Auth *RedditAuth
+}
+
+// multiPath turns a multireddit like "user/m/tech", "u/user/m/tech" or
+// "/user/user/m/tech" into the path of its listing
+func multiPath(multi string) (string, error) {
+	parts := strings.Split(strings.Trim(multi, "/"), "/")
+	if len(parts) == 4 && (parts[0] == "user" || parts[0] == "u") {
+		parts = parts[1:]
+	}
+	if len(parts) != 3 || parts[0] == "" || parts[1] != "m" || parts[2] == "" {
+		return "", fmt.Errorf("multireddit %q should look like user/m/name", multi)
+	}
+	return fmt.Sprintf("/user/%s/m/%s", parts[0], parts[2]), nil
+}
+
+// listingURL returns the URL of the listing page following after
+func (rs *RedditSource) listingURL(limit int, after string) (string, error) {
+	sort, err := lookupMode("reddit sort", rs.Sort, "hot", redditSorts)
+	if err != nil {
+		return "", err
+	}
+
+	v := url.Values{}
+	v.Set("limit", strconv.Itoa(limit))
+	if after != "" {
+		v.Set("after", after)
+	}
+
+	// reddit merges r/a+b+c into a single listing
+	path := "/r/" + DefaultSubreddit
+	if len(rs.Subreddits) > 0 {
+		path = "/r/" + strings.Join(rs.Subreddits, "+")
+	}
+	if rs.Multi != "" {
+		if len(rs.Subreddits) > 0 {
+			return "", errors.New("use either subreddits or a multireddit, not both")
+		}
+		if path, err = multiPath(rs.Multi); err != nil {
+			return "", err
+		}
+	}
+
+	switch rs.Mode {
+	case "":
+	case "saved":
+		if rs.Auth == nil {
+			return "", errors.New("reading saved posts needs reddit credentials in the config file")
+		}
+		path = "/user/" + rs.Auth.Credentials.Username + "/saved"
+		v.Set("sort", sort)
+		sort = ""
+	default:
+		return "", fmt.Errorf("unknown reddit mode %q (one of saved)", rs.Mode)
+	}
+
+	if rs.Time != "" {
+		window, err := lookupMode("reddit time", rs.Time, "", redditTimes)
+		if err != nil {
+			return "", err
+		}
+		v.Set("t", window)
+	}
+
+	base := RedditURL
+	if rs.Auth != nil {
+		base = RedditOAuthURL
+	}
+	if sort != "" {
+		path += "/" + sort
+	}
+	return fmt.Sprintf("%s%s.json?%s", base, path, v.Encode()), nil
+}
+
+// Fetch gets news from the Reddit
+func (rs *RedditSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	var news []Story
+	// the same link is often posted to several of the subreddits
+	seen := make(map[string]bool)
+	after := ""
+
+	for len(news) < count {
+		limit := count - len(news)
+		if limit > redditMaxLimit {
+			limit = redditMaxLimit
+		}
+
+		u, err := rs.listingURL(limit, after)
+		if err != nil {
+			return news, err
+		}
+
+		var header http.Header
+		if rs.Auth != nil {
+			token, err := rs.Auth.Token(ctx)
+			if err != nil {
+				return news, err
+			}
+			header = http.Header{"Authorization": {"bearer " + token}}
+		}
+
+		listing := redditListing{}
+		if err := getJSONWithHeader(ctx, rs.Client, u, header, &listing); err != nil {
+			return news, err
+		}
+
+		for _, child := range listing.Data.Children {
+			sub := child.Data
+			// saved listings mix in comments, which have no link
+			if sub.URL == "" || seen[sub.URL] || len(news) >= count {
+				continue
+			}
+			if sub.IsNSFW && !rs.NSFW || sub.IsSelf && rs.LinksOnly {
+				continue
+			}
+			seen[sub.URL] = true
+
+			news = append(news, Story{
+				Title:       sub.Title,
+				URL:         sub.URL,
+				CommentsURL: sub.FullPermalink(),
+				Score:       sub.Score,
+				HasScore:    true,
+				Comments:    sub.NumComments,
+				Author:      sub.Author,
+				Tags:        tags([]string{sub.LinkFlairText}),
+				Source:      "reddit",
+				PublishedAt: time.Unix(int64(sub.DateCreated), 0).UTC(),
+			})
+		}
+
+		after = listing.Data.After
+		if after == "" {
+			break
+		}
+	}
+
+	return news, nil
+}
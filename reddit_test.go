@@ -0,0 +1,122 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const redditListingJSON = `{"data": {"children": [
+{"data": {"title": "Go 2", "url": "https://blog.golang.org/go2", "permalink": "/r/programming/comments/x1/go_2/", "score": 120, "created_utc": 1538492645, "author": "rob", "link_flair_text": "News"}},
+{"data": {"title": "Rust", "url": "https://www.rust-lang.org", "permalink": "/r/programming/comments/x2/rust/", "score": 80, "created_utc": 1538492000}}
+]}}`
+
+func TestRedditFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/r/programming/hot.json", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		w.Write([]byte(redditListingJSON))
+	}))
+	defer done()
+
+	news, err := (&RedditSource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+
+	assert.Equal(t, "Go 2", news[0].Title)
+	assert.Equal(t, "https://blog.golang.org/go2", news[0].URL)
+	assert.Equal(t, "https://reddit.com/r/programming/comments/x1/go_2/", news[0].CommentsURL)
+	assert.Equal(t, 120, news[0].Score)
+	assert.Equal(t, "reddit", news[0].Source)
+	assert.Equal(t, int64(1538492645), news[0].PublishedAt.Unix())
+	assert.Equal(t, "rob", news[0].Author)
+	assert.Equal(t, []string{"News"}, news[0].Tags)
+	assert.Nil(t, news[1].Tags)
+}
+
+func TestRedditFetchSubreddits(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data": {"after": "t3_x2", "children": [
+			{"data": {"title": "Go", "url": "https://golang.org", "permalink": "/r/golang/comments/x1/go/"}},
+			{"data": {"title": "Go again", "url": "https://golang.org", "permalink": "/r/rust/comments/x2/go/"}}
+		]}}`,
+		"t3_x2": `{"data": {"after": "", "children": [
+			{"data": {"title": "Rust", "url": "https://www.rust-lang.org", "permalink": "/r/rust/comments/x3/rust/"}}
+		]}}`,
+	}
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/r/golang+rust/hot.json", r.URL.Path)
+		w.Write([]byte(pages[r.URL.Query().Get("after")]))
+	}))
+	defer done()
+
+	src := &RedditSource{Client: client, Subreddits: []string{"golang", "rust"}}
+	news, err := src.Fetch(context.Background(), 5)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "Go", news[0].Title)
+	assert.Equal(t, "Rust", news[1].Title)
+}
+
+func TestRedditFetchContentFilters(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"children": [
+			{"data": {"title": "Link", "url": "https://example.com", "permalink": "/r/programming/comments/x1/link/"}},
+			{"data": {"title": "Text", "url": "https://www.reddit.com/r/programming/comments/x2/text/", "permalink": "/r/programming/comments/x2/text/", "is_self": true}},
+			{"data": {"title": "NSFW", "url": "https://example.org", "permalink": "/r/programming/comments/x3/nsfw/", "over_18": true}}
+		]}}`))
+	}))
+	defer done()
+
+	titles := func(src *RedditSource) []string {
+		src.Client = client
+		news, err := src.Fetch(context.Background(), 3)
+		assert.Nil(t, err)
+		var titles []string
+		for _, story := range news {
+			titles = append(titles, story.Title)
+		}
+		return titles
+	}
+	assert.Equal(t, []string{"Link", "Text"}, titles(&RedditSource{}))
+	assert.Equal(t, []string{"Link", "Text", "NSFW"}, titles(&RedditSource{NSFW: true}))
+	assert.Equal(t, []string{"Link"}, titles(&RedditSource{LinksOnly: true}))
+}
+
+func TestRedditListingURL(t *testing.T) {
+	u, err := (&RedditSource{}).listingURL(10, "")
+	assert.Nil(t, err)
+	assert.Equal(t, RedditURL+"/r/programming/hot.json?limit=10", u)
+
+	u, err = (&RedditSource{Subreddits: []string{"golang"}, Sort: "top", Time: "week"}).listingURL(5, "t3_x")
+	assert.Nil(t, err)
+	assert.Equal(t, RedditURL+"/r/golang/top.json?after=t3_x&limit=5&t=week", u)
+
+	_, err = (&RedditSource{Sort: "best"}).listingURL(5, "")
+	assert.EqualError(t, err, `unknown reddit sort "best" (one of hot, new, rising, top)`)
+
+	_, err = (&RedditSource{Time: "decade"}).listingURL(5, "")
+	assert.NotNil(t, err)
+
+	u, err = (&RedditSource{Multi: "spez/m/tech"}).listingURL(5, "")
+	assert.Nil(t, err)
+	assert.Equal(t, RedditURL+"/user/spez/m/tech/hot.json?limit=5", u)
+
+	_, err = (&RedditSource{Multi: "spez/m/tech", Subreddits: []string{"golang"}}).listingURL(5, "")
+	assert.NotNil(t, err)
+}
+
+func TestMultiPath(t *testing.T) {
+	for _, multi := range []string{"spez/m/tech", "u/spez/m/tech", "/user/spez/m/tech/"} {
+		path, err := multiPath(multi)
+		assert.Nil(t, err)
+		assert.Equal(t, "/user/spez/m/tech", path)
+	}
+
+	for _, multi := range []string{"", "tech", "spez/tech", "spez/x/tech", "user/spez/m/"} {
+		_, err := multiPath(multi)
+		assert.NotNil(t, err, multi)
+	}
+}
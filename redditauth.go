@@ -0,0 +1,161 @@
+package hnreader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Reddit OAuth endpoints
+const (
+	RedditTokenURL = "https://www.reddit.com/api/v1/access_token"
+	RedditOAuthURL = "https://oauth.reddit.com"
+)
+
+// RedditCredentials belong to a reddit "script" app created at
+// https://www.reddit.com/prefs/apps, and the account that owns it.
+type RedditCredentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+}
+
+// Configured reports whether enough credentials are set to log in.
+func (c RedditCredentials) Configured() bool {
+	return c.ClientID != "" && c.Username != "" && c.Password != ""
+}
+
+// redditToken is an access token together with its expiry
+type redditToken struct {
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// valid reports whether the token can still be used for a while
+func (t redditToken) valid() bool {
+	return t.AccessToken != "" && time.Now().Add(time.Minute).Before(t.Expiry)
+}
+
+// RedditAuth logs into reddit with the script app flow and keeps the access
+// token in TokenFile, so following runs don't need to log in again.
+type RedditAuth struct {
+	Credentials RedditCredentials
+	// TokenFile stores the access token between runs, nothing is stored
+	// when it is empty
+	TokenFile string
+	// Client is used to request tokens, http.DefaultClient when nil
+	Client *http.Client
+
+	token redditToken
+}
+
+// RedditTokenFile returns where the reddit access token is kept by default.
+func RedditTokenFile() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "reddit-token.json"), nil
+}
+
+// Token returns a valid access token, logging in when the stored one has
+// expired.
+func (a *RedditAuth) Token(ctx context.Context) (string, error) {
+	if a.token.valid() {
+		return a.token.AccessToken, nil
+	}
+
+	if a.TokenFile != "" {
+		if data, err := ioutil.ReadFile(a.TokenFile); err == nil {
+			var stored redditToken
+			if json.Unmarshal(data, &stored) == nil && stored.valid() {
+				a.token = stored
+				return stored.AccessToken, nil
+			}
+		}
+	}
+
+	token, err := a.login(ctx)
+	if err != nil {
+		return "", err
+	}
+	a.token = token
+
+	if a.TokenFile != "" {
+		if err := a.save(); err != nil {
+			handleError(err)
+		}
+	}
+	return token.AccessToken, nil
+}
+
+// login requests a new access token with the password grant
+func (a *RedditAuth) login(ctx context.Context) (redditToken, error) {
+	if !a.Credentials.Configured() {
+		return redditToken{}, errors.New("reddit credentials are not configured")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", a.Credentials.Username)
+	form.Set("password", a.Credentials.Password)
+
+	req, err := http.NewRequest(http.MethodPost, RedditTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return redditToken{}, err
+	}
+	req.SetBasicAuth(a.Credentials.ClientID, a.Credentials.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return redditToken{}, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return redditToken{}, fmt.Errorf("reddit login: %s", resp.Status)
+	}
+	if body.AccessToken == "" {
+		if body.Error == "" {
+			body.Error = resp.Status
+		}
+		return redditToken{}, fmt.Errorf("reddit login failed: %s", body.Error)
+	}
+
+	return redditToken{
+		AccessToken: body.AccessToken,
+		Expiry:      time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// save writes the current token to TokenFile, readable by the user only
+func (a *RedditAuth) save() error {
+	data, err := json.Marshal(a.token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(a.TokenFile), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(a.TokenFile, data, 0600)
+}
@@ -0,0 +1,90 @@
+package hnreader
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedditAuthToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader-token")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	logins := 0
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		id, secret, _ := r.BasicAuth()
+		assert.Equal(t, "id", id)
+		assert.Equal(t, "secret", secret)
+		assert.Equal(t, "password", r.FormValue("grant_type"))
+		assert.Equal(t, "spez", r.FormValue("username"))
+		w.Write([]byte(`{"access_token": "tok", "expires_in": 3600}`))
+	}))
+	defer done()
+
+	creds := RedditCredentials{ClientID: "id", ClientSecret: "secret", Username: "spez", Password: "hunter2"}
+	tokenFile := filepath.Join(dir, "token.json")
+
+	auth := &RedditAuth{Credentials: creds, TokenFile: tokenFile, Client: client}
+	token, err := auth.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "tok", token)
+
+	// a fresh RedditAuth reuses the stored token
+	auth = &RedditAuth{Credentials: creds, TokenFile: tokenFile, Client: client}
+	token, err = auth.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "tok", token)
+	assert.Equal(t, 1, logins)
+}
+
+func TestRedditAuthFailure(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid_grant"}`))
+	}))
+	defer done()
+
+	auth := &RedditAuth{Credentials: RedditCredentials{ClientID: "id", Username: "spez", Password: "nope"}, Client: client}
+	_, err := auth.Token(context.Background())
+	assert.EqualError(t, err, "reddit login failed: invalid_grant")
+
+	_, err = (&RedditAuth{}).Token(context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestRedditFetchAuthenticated(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			w.Write([]byte(`{"access_token": "tok", "expires_in": 3600}`))
+			return
+		}
+		assert.Equal(t, "bearer tok", r.Header.Get("Authorization"))
+		assert.Equal(t, "/user/spez/saved.json", r.URL.Path)
+		w.Write([]byte(`{"data": {"children": [
+			{"data": {"body": "a saved comment"}},
+			{"data": {"title": "Saved", "url": "https://example.com/saved", "permalink": "/r/golang/comments/x1/saved/"}}
+		]}}`))
+	}))
+	defer done()
+
+	auth := &RedditAuth{Credentials: RedditCredentials{ClientID: "id", Username: "spez", Password: "hunter2"}, Client: client}
+	src := &RedditSource{Client: client, Mode: "saved", Auth: auth}
+	news, err := src.Fetch(context.Background(), 5)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "Saved", news[0].Title)
+
+	_, err = (&RedditSource{Mode: "saved"}).listingURL(5, "")
+	assert.NotNil(t, err)
+
+	u, err := (&RedditSource{Auth: auth}).listingURL(5, "")
+	assert.Nil(t, err)
+	assert.Equal(t, RedditOAuthURL+"/r/programming/hot.json?limit=5", u)
+}
@@ -0,0 +1,97 @@
+package hnreader
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry defaults
+const (
+	DefaultRetries      = 2
+	DefaultRetryBackoff = 500 * time.Millisecond
+	DefaultRetryJitter  = 0.2
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// RetryTransport retries requests that fail with a network error, 429 Too
+// Many Requests or a 5xx status. The delay starts at Backoff and doubles
+// after every attempt, randomised by Jitter.
+type RetryTransport struct {
+	// Retries is the number of extra attempts after the first one
+	Retries int
+	// Backoff is the delay before the first retry
+	Backoff time.Duration
+	// Jitter spreads each delay by up to this fraction, between 0 and 1
+	Jitter float64
+	// Transport makes the actual requests, http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// only requests without a body can be replayed safely
+	if req.Body != nil && req.Body != http.NoBody {
+		return transport.RoundTrip(req)
+	}
+
+	backoff := t.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := transport.RoundTrip(req)
+		if attempt >= t.Retries || req.Context().Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+
+		delay := t.delay(backoff)
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				delay = after
+			}
+			resp.Body.Close()
+		}
+		if delay > maxRetryBackoff {
+			delay = maxRetryBackoff
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+		backoff *= 2
+	}
+}
+
+// delay applies the jitter to backoff
+func (t *RetryTransport) delay(backoff time.Duration) time.Duration {
+	if t.Jitter <= 0 {
+		return backoff
+	}
+	spread := (rand.Float64()*2 - 1) * t.Jitter
+	return backoff + time.Duration(float64(backoff)*spread)
+}
+
+// retryable reports whether the outcome of a request is worth another try
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryAfter returns the delay asked for by a Retry-After header in seconds
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
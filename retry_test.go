@@ -0,0 +1,86 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Retries: 2, Backoff: time.Millisecond}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Retries: 1, Backoff: time.Millisecond}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryTransportNotFound(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Retries: 3, Backoff: time.Millisecond}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryTransportCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	client := &http.Client{Transport: &RetryTransport{Retries: 5, Backoff: time.Hour}}
+	_, err := client.Do(req.WithContext(ctx))
+	assert.NotNil(t, err)
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	transport := &RetryTransport{Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay := transport.delay(time.Second)
+		assert.True(t, delay >= 500*time.Millisecond && delay <= 1500*time.Millisecond)
+	}
+	assert.Equal(t, time.Second, new(RetryTransport).delay(time.Second))
+}
@@ -0,0 +1,119 @@
+package hnreader
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// Rss decode RSS xml
+type Rss struct {
+	Item []RssItem `xml:"channel>item"`
+}
+
+// RssItem item with link to news
+type RssItem struct {
+	Title    string `xml:"title"`
+	Link     string `xml:"link"`
+	Comments string `xml:"comments"`
+	PubDate  string `xml:"pubDate"`
+	// Date is the Dublin Core date used by RSS 1.0 feeds
+	Date string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	// Creator is the Dublin Core author, which unlike author is not
+	// required to be an email address
+	Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Author  string `xml:"author"`
+	// Categories are the tags of the item
+	Categories []string `xml:"category"`
+}
+
+// Story converts the RSS item into a Story from the given source
+func (item RssItem) Story(source string) Story {
+	story := Story{
+		Title:       strings.TrimSpace(item.Title),
+		URL:         strings.TrimSpace(item.Link),
+		CommentsURL: strings.TrimSpace(item.Comments),
+		Author:      strings.TrimSpace(item.Creator),
+		Source:      source,
+		PublishedAt: parseTime(item.PubDate),
+	}
+	if story.PublishedAt.IsZero() {
+		story.PublishedAt = parseTime(item.Date)
+	}
+	if story.Author == "" {
+		story.Author = strings.TrimSpace(item.Author)
+	}
+	story.Tags = tags(item.Categories)
+	return story
+}
+
+// rssDoc is the RSS 2.0 document written by WriteRSS
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is the channel of a written RSS document
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	LastBuildDate string       `xml:"lastBuildDate"`
+	Items         []rssOutItem `xml:"item"`
+}
+
+// rssOutItem is an item of a written RSS document
+type rssOutItem struct {
+	Title    string  `xml:"title"`
+	Link     string  `xml:"link"`
+	Comments string  `xml:"comments,omitempty"`
+	GUID     rssGUID `xml:"guid"`
+	PubDate  string  `xml:"pubDate,omitempty"`
+	Category string  `xml:"category,omitempty"`
+}
+
+// rssGUID identifies an item, it is a permalink when it is the story's URL
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// WriteRSS writes the stories as an RSS 2.0 feed titled title, whose
+// channel links to link. The source of each story becomes its category.
+func WriteRSS(w io.Writer, title, link string, news []Story) error {
+	doc := rssDoc{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          link,
+			Description:   AppDescription,
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, story := range news {
+		item := rssOutItem{
+			Title:    story.Title,
+			Link:     story.URL,
+			Comments: story.CommentsURL,
+			GUID:     rssGUID{Value: story.key(), IsPermaLink: story.key() == story.URL},
+			Category: story.Source,
+		}
+		if !story.PublishedAt.IsZero() {
+			item.PubDate = story.PublishedAt.Format(time.RFC1123Z)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,256 @@
+package hnreader
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rssFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Feed</title>
+<item><title>First</title><link>https://example.com/1</link><pubDate>Tue, 02 Oct 2018 15:04:05 +0000</pubDate></item>
+<item><title>Second</title><link>https://example.com/2</link></item>
+<item><title>Third</title><link>https://example.com/3</link></item>
+</channel></rss>`
+
+func TestFetchRss(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&DZoneSource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "First", news[0].Title)
+	assert.Equal(t, "https://example.com/2", news[1].URL)
+	assert.Equal(t, "dzone", news[1].Source)
+
+	news, err = (&DevToSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "devto", news[2].Source)
+}
+
+func TestRssItemStory(t *testing.T) {
+	item := RssItem{
+		Title:    " Hello ",
+		Link:     "https://example.com/hello\n",
+		Comments: "https://example.com/hello#comments",
+		PubDate:  "Tue, 02 Oct 2018 15:04:05 +0000",
+	}
+
+	story := item.Story("dzone")
+	assert.Equal(t, "Hello", story.Title)
+	assert.Equal(t, "https://example.com/hello", story.URL)
+	assert.Equal(t, "https://example.com/hello#comments", story.CommentsURL)
+	assert.Equal(t, "dzone", story.Source)
+	assert.Equal(t, 2018, story.PublishedAt.Year())
+}
+
+func TestDevToFetchTags(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag := strings.TrimPrefix(r.URL.Path, "/feed/tag/")
+		w.Write([]byte(`<rss><channel>
+<item><title>` + tag + ` 1</title><link>https://dev.to/` + tag + `/1</link></item>
+<item><title>` + tag + ` 2</title><link>https://dev.to/` + tag + `/2</link></item>
+</channel></rss>`))
+	}))
+	defer done()
+
+	news, err := (&DevToSource{Client: client, Tags: []string{"go", "webdev"}}).Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "go 1", news[0].Title)
+		assert.Equal(t, "webdev 1", news[1].Title)
+		assert.Equal(t, "go 2", news[2].Title)
+	}
+}
+
+func TestDZoneFetchZone(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/java", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&DZoneSource{Client: client, Zone: "java"}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+
+	_, err = (&DZoneSource{Client: client, Zone: "cobol"}).Fetch(context.Background(), 1)
+	assert.Contains(t, err.Error(), `unknown dzone zone "cobol"`)
+}
+
+func TestArsTechnicaFetchSection(t *testing.T) {
+	var paths []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&ArsTechnicaSource{Client: client}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "ars", news[0].Source)
+
+	_, err = (&ArsTechnicaSource{Client: client, Section: "tech-policy"}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/arstechnica/index", "/arstechnica/tech-policy"}, paths)
+
+	_, err = (&ArsTechnicaSource{Client: client, Section: "gossip"}).Fetch(context.Background(), 1)
+	assert.NotNil(t, err)
+}
+
+func TestInfoQFetchTopic(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/architecture-design/", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&InfoQSource{Client: client, Topic: "architecture"}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "infoq", news[0].Source)
+}
+
+func TestHackerNoonFetchTags(t *testing.T) {
+	var paths []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&HackerNoonSource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "hackernoon", news[0].Source)
+
+	news, err = (&HackerNoonSource{Client: client, Tags: []string{"golang"}}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, []string{"/feed", "/tagged/golang/feed"}, paths)
+}
+
+func TestArxivFetchCategories(t *testing.T) {
+	var paths []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&ArxivSource{Client: client}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "arxiv", news[0].Source)
+
+	_, err = (&ArxivSource{Client: client, Categories: []string{"cs.LG", "cs.DC"}}).Fetch(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/rss/cs", "/rss/cs.LG+cs.DC"}, paths)
+}
+
+func TestPhoronixFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rss.php", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&PhoronixSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "phoronix", news[0].Source)
+}
+
+func TestChangelogFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/news/feed", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&ChangelogSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "changelog", news[0].Source)
+}
+
+func TestFreeCodeCampFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/news/rss/", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&FreeCodeCampSource{Client: client}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Len(t, news, 1)
+	assert.Equal(t, "freecodecamp", news[0].Source)
+}
+
+func TestGoogleNewsFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rss/headlines/section/topic/SCIENCE", r.URL.Path)
+		assert.Equal(t, "fr-CA", r.URL.Query().Get("hl"))
+		assert.Equal(t, "CA:fr", r.URL.Query().Get("ceid"))
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&GoogleNewsSource{Client: client, Topic: "science", Locale: "fr-CA"}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "gnews", news[0].Source)
+}
+
+func TestGoogleNewsEdition(t *testing.T) {
+	v := googleNewsEdition("")
+	assert.Equal(t, "en-US", v.Get("hl"))
+	assert.Equal(t, "US", v.Get("gl"))
+	assert.Equal(t, "US:en", v.Get("ceid"))
+
+	assert.Equal(t, "US:de", googleNewsEdition("de").Get("ceid"))
+}
+
+func TestSidebarFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/feed.xml", r.URL.Path)
+		w.Write([]byte(rssFeed))
+	}))
+	defer done()
+
+	news, err := (&SidebarSource{Client: client}).Fetch(context.Background(), 5)
+	assert.Nil(t, err)
+	assert.Len(t, news, 3)
+	assert.Equal(t, "sidebar", news[0].Source)
+}
+
+func TestWriteRSS(t *testing.T) {
+	published := time.Date(2018, 10, 2, 15, 4, 5, 0, time.UTC)
+	news := []Story{
+		{Title: "Hello & welcome", URL: "https://example.com/hello", CommentsURL: "https://news.ycombinator.com/item?id=1", Source: "hn", PublishedAt: published},
+		{Title: "Plain", URL: "https://example.com/plain"},
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteRSS(&buf, "hnreader", "https://github.com/Bunchhieng/hnreader", news))
+	assert.Contains(t, buf.String(), `<rss version="2.0">`)
+	assert.Contains(t, buf.String(), `<guid isPermaLink="true">https://example.com/plain</guid>`)
+
+	// the feed reads back into the same stories
+	read, err := parseFeed(&buf, "rss")
+	assert.Nil(t, err)
+	assert.Len(t, read, 2)
+	assert.Equal(t, news[0].Title, read[0].Title)
+	assert.Equal(t, news[0].URL, read[0].URL)
+	assert.Equal(t, news[0].CommentsURL, read[0].CommentsURL)
+	assert.True(t, published.Equal(read[0].PublishedAt))
+}
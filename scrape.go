@@ -0,0 +1,145 @@
+package hnreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ScrapeConfig declares a source scraped with a CSS selector, so that sites
+// can be added from the configuration file without recompiling.
+type ScrapeConfig struct {
+	// Name is the value given to --source
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// URL of the listing. A {page} placeholder is replaced with the page
+	// number, starting at FirstPage, for sites spread over several pages.
+	URL string `json:"url"`
+	// Links selects the story links of a page, like "h2.title a"
+	Links string `json:"links"`
+	// FirstPage is the number of the first page, 1 when zero
+	FirstPage int `json:"first_page"`
+	// MaxPages limits how many pages are read, 10 when zero
+	MaxPages int `json:"max_pages"`
+}
+
+// RegisterScrapers registers a source for every scraper in configs. Unlike
+// Register it reports invalid declarations and taken names instead of
+// panicking, as they come from the user.
+func RegisterScrapers(configs []ScrapeConfig) error {
+	for _, config := range configs {
+		if config.Name == "" || config.URL == "" || config.Links == "" {
+			return fmt.Errorf("source %q needs a name, a url and a links selector", config.Name)
+		}
+		if isRegistered(config.Name) {
+			return fmt.Errorf("source %q is already defined", config.Name)
+		}
+
+		config := config
+		description := config.Description
+		if description == "" {
+			description = "Links scraped from " + config.URL
+		}
+		Register(Source{
+			Name:        config.Name,
+			Description: description,
+			New: func(opts Options) Fetcher {
+				return &ScrapeSource{Client: opts.Client, Workers: opts.Workers, Config: config}
+			},
+		})
+	}
+	return nil
+}
+
+// isRegistered tells whether a source already uses name
+func isRegistered(name string) bool {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	_, ok := sources[name]
+	return ok
+}
+
+// ScrapeSource fetches the links a ScrapeConfig selects
+type ScrapeSource struct {
+	Client *http.Client
+	// Workers is the number of pages fetched at once
+	Workers int
+	Config  ScrapeConfig
+}
+
+// Fetch gets the links of as many pages as needed
+func (l *ScrapeSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	if l.Config.URL == "" || l.Config.Links == "" {
+		return nil, errors.New("the scraped source needs a url and a links selector")
+	}
+
+	maxPages := l.Config.MaxPages
+	if maxPages == 0 {
+		maxPages = 10
+	}
+	if !strings.Contains(l.Config.URL, "{page}") {
+		maxPages = 1
+	}
+
+	// the number of links per page is unknown, so pages are read one by one
+	p := pager{perPage: count, workers: l.Workers, fetch: func(ctx context.Context, page int) ([]Story, error) {
+		if page > maxPages {
+			return nil, nil
+		}
+		return l.fetchPage(ctx, page)
+	}}
+	return p.collect(ctx, count)
+}
+
+// fetchPage scrapes the links of the nth page
+func (l *ScrapeSource) fetchPage(ctx context.Context, page int) ([]Story, error) {
+	first := l.Config.FirstPage
+	if first == 0 {
+		first = 1
+	}
+	pageURL := strings.Replace(l.Config.URL, "{page}", strconv.Itoa(first+page-1), -1)
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := get(ctx, l.Client, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find(l.Config.Links).Each(func(_ int, s *goquery.Selection) {
+		href, exist := s.Attr("href")
+		if !exist {
+			return
+		}
+		link, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+
+		news = append(news, Story{
+			Title:  strings.TrimSpace(s.Text()),
+			URL:    link.String(),
+			Source: l.Config.Name,
+		})
+	})
+
+	return news, nil
+}
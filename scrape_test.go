@@ -0,0 +1,50 @@
+package hnreader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrapeSourceFetch(t *testing.T) {
+	var pages []string
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("p")
+		pages = append(pages, page)
+		fmt.Fprintf(w, `<h2 class="title"><a href="/post/%s-1"> Post %s.1 </a></h2>
+<h2 class="title"><a href="https://other.example.com/%s-2">Post %s.2</a></h2>
+<a href="/about">About</a>`, page, page, page, page)
+	}))
+	defer done()
+
+	src := &ScrapeSource{Client: client, Workers: 1, Config: ScrapeConfig{
+		Name:     "blog",
+		URL:      "https://blog.example.com/?p={page}",
+		Links:    "h2.title a",
+		MaxPages: 2,
+	}}
+	news, err := src.Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "Post 1.1", news[0].Title)
+		assert.Equal(t, "https://blog.example.com/post/1-1", news[0].URL)
+		assert.Equal(t, "https://other.example.com/1-2", news[1].URL)
+		assert.Equal(t, "blog", news[2].Source)
+	}
+
+	news, err = src.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 4)
+}
+
+func TestRegisterScrapers(t *testing.T) {
+	err := RegisterScrapers([]ScrapeConfig{{Name: "test-scraped", URL: "https://example.com", Links: "a"}})
+	assert.Nil(t, err)
+	assert.Contains(t, SourceNames(), "test-scraped")
+
+	assert.NotNil(t, RegisterScrapers([]ScrapeConfig{{Name: "test-scraped", URL: "https://example.com", Links: "a"}}))
+	assert.NotNil(t, RegisterScrapers([]ScrapeConfig{{Name: "test-incomplete"}}))
+}
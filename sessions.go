@@ -0,0 +1,94 @@
+package hnreader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SessionsDir returns the sessions directory inside ConfigDir, which keeps
+// the stories opened by each named session in a JSON file.
+func SessionsDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sessions"), nil
+}
+
+// Session is a named batch of stories opened together.
+type Session struct {
+	Name    string
+	SavedAt time.Time
+	Stories []Story
+}
+
+// sessionPath returns the file of the named session in dir
+func sessionPath(dir, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%q is not a session name, which can't be empty, start with a dot or hold slashes", name)
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// SaveSession saves the stories as the named session in dir, replacing the
+// session of that name if there is one.
+func SaveSession(dir, name string, news []Story) error {
+	path, err := sessionPath(dir, name)
+	if err != nil {
+		return err
+	}
+	return SaveStories(path, news)
+}
+
+// LoadSession reads the named session saved in dir.
+func LoadSession(dir, name string) (*Session, error) {
+	path, err := sessionPath(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no session named %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	news, err := LoadStories(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Name: name, SavedAt: info.ModTime(), Stories: news}, nil
+}
+
+// Sessions reads every session saved in dir, the last saved first.
+func Sessions(dir string) ([]*Session, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), ".json")
+		if file.IsDir() || name == file.Name() {
+			continue
+		}
+		session, err := LoadSession(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].SavedAt.After(sessions[j].SavedAt)
+	})
+	return sessions, nil
+}
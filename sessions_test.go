@@ -0,0 +1,47 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "sessions")
+
+	sessions, err := Sessions(dir)
+	assert.Nil(t, err)
+	assert.Empty(t, sessions)
+
+	monday := []Story{{Title: "One", URL: "https://example.com/1"}, {Title: "Two", URL: "https://example.com/2"}}
+	assert.Nil(t, SaveSession(dir, "monday", monday))
+	assert.Nil(t, SaveSession(dir, "tuesday", monday[1:]))
+	// the newest session is listed first
+	past := time.Now().Add(-time.Hour)
+	assert.Nil(t, os.Chtimes(filepath.Join(dir, "monday.json"), past, past))
+
+	session, err := LoadSession(dir, "monday")
+	assert.Nil(t, err)
+	assert.Equal(t, "monday", session.Name)
+	assert.Len(t, session.Stories, 2)
+	assert.Equal(t, monday[0].URL, session.Stories[0].URL)
+
+	sessions, err = Sessions(dir)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "tuesday", sessions[0].Name)
+	assert.Equal(t, "monday", sessions[1].Name)
+
+	_, err = LoadSession(dir, "sunday")
+	assert.EqualError(t, err, `no session named "sunday"`)
+	for _, name := range []string{"", "../config", ".hidden", `a\b`} {
+		assert.NotNil(t, SaveSession(dir, name, monday), name)
+	}
+}
@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// SidebarURL is the Sidebar feed
+const SidebarURL = "https://sidebar.io/feed.xml"
+
+func init() {
+	Register(Source{
+		Name:        "sidebar",
+		Description: "The five daily design links of sidebar.io",
+		New: func(opts Options) Fetcher {
+			return &SidebarSource{Client: opts.Client}
+		},
+	})
+}
+
+// SidebarSource fetches the design links of https://sidebar.io/
+type SidebarSource struct {
+	Client *http.Client
+}
+
+// Fetch gets the latest links from Sidebar
+func (l *SidebarSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return fetchFeed(ctx, l.Client, SidebarURL, "sidebar", count)
+}
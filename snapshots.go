@@ -0,0 +1,51 @@
+package hnreader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+)
+
+// SnapshotsDir returns the snapshots directory inside ConfigDir, which keeps
+// the stories last fetched by hnreader diff for each listing.
+func SnapshotsDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots"), nil
+}
+
+// SnapshotFile returns the file in dir keeping the snapshot of the listing
+// described by key, like "source=hn mode=best". The file is named after
+// the key, with a hash telling apart keys that read the same.
+func SnapshotFile(dir, key string) string {
+	slug := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, key)
+	if len(slug) > 40 {
+		slug = slug[:40]
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, slug+"-"+hex.EncodeToString(sum[:4])+".json")
+}
+
+// NewStories returns the stories of current that aren't in previous, by
+// their canonical URL, in the order of current.
+func NewStories(previous, current []Story) []Story {
+	seen := make(map[string]bool, len(previous))
+	for _, story := range previous {
+		seen[story.articleKey()] = true
+	}
+	var news []Story
+	for _, story := range current {
+		if !seen[story.articleKey()] {
+			news = append(news, story)
+		}
+	}
+	return news
+}
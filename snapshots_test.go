@@ -0,0 +1,36 @@
+package hnreader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotFile(t *testing.T) {
+	path := SnapshotFile("dir", "source=hn mode=best")
+	assert.Equal(t, "dir", filepath.Dir(path))
+	assert.Regexp(t, `^source-hn-mode-best-[0-9a-f]{8}\.json$`, filepath.Base(path))
+	// keys that make the same name are told apart
+	assert.NotEqual(t, path, SnapshotFile("dir", "source=hn mode-best"))
+	assert.Equal(t, path, SnapshotFile("dir", "source=hn mode=best"))
+}
+
+func TestNewStories(t *testing.T) {
+	previous := []Story{
+		{Title: "Old", URL: "https://example.com/old"},
+		{Title: "Kept", URL: "https://www.example.com/kept?utm_source=hn"},
+	}
+	current := []Story{
+		{Title: "First", URL: "https://example.com/first"},
+		{Title: "Kept", URL: "http://example.com/kept"},
+		{Title: "Second", URL: "https://example.com/second"},
+	}
+	news := NewStories(previous, current)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "First", news[0].Title)
+	assert.Equal(t, "Second", news[1].Title)
+
+	assert.Len(t, NewStories(nil, current), 3)
+	assert.Empty(t, NewStories(current, current))
+}
@@ -0,0 +1,67 @@
+package hnreader
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Keys stories can be sorted by
+const (
+	SortScore    = "score"
+	SortComments = "comments"
+	SortDate     = "date"
+	SortSource   = "source"
+	SortTitle    = "title"
+)
+
+// SortKeys lists the keys SortStories accepts
+var SortKeys = []string{SortScore, SortComments, SortDate, SortSource, SortTitle}
+
+// storyLess compares two stories by one of SortKeys
+var storyLess = map[string]func(a, b Story) bool{
+	SortScore:    func(a, b Story) bool { return a.Score < b.Score },
+	SortComments: func(a, b Story) bool { return a.Comments < b.Comments },
+	SortDate:     func(a, b Story) bool { return a.PublishedAt.Before(b.PublishedAt) },
+	SortSource:   func(a, b Story) bool { return a.Source < b.Source },
+	SortTitle:    func(a, b Story) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) },
+}
+
+// SortStories sorts the stories in place by key, one of SortKeys, in
+// ascending order unless desc is set. Equal stories keep their order.
+func SortStories(news []Story, key string, desc bool) error {
+	less, ok := storyLess[key]
+	if !ok {
+		return fmt.Errorf("unknown sort key %q (one of %s)", key, strings.Join(SortKeys, ", "))
+	}
+	sort.SliceStable(news, func(i, j int) bool {
+		if desc {
+			return less(news[j], news[i])
+		}
+		return less(news[i], news[j])
+	})
+	return nil
+}
+
+// Shuffle puts the stories in a random order, in place.
+func Shuffle(news []Story, rng *rand.Rand) {
+	rng.Shuffle(len(news), func(i, j int) {
+		news[i], news[j] = news[j], news[i]
+	})
+}
+
+// Sample returns n of the stories picked at random, in the order they are
+// given. All the stories are returned when there are no more than n.
+func Sample(news []Story, n int, rng *rand.Rand) []Story {
+	if n >= len(news) {
+		return news
+	}
+	picked := rng.Perm(len(news))[:n]
+	sort.Ints(picked)
+	sample := make([]Story, n)
+	for i, index := range picked {
+		sample[i] = news[index]
+	}
+	return sample
+}
@@ -0,0 +1,56 @@
+package hnreader
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortStories(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	news := []Story{
+		{Title: "beta", Score: 10, Comments: 3, Source: "reddit", PublishedAt: day},
+		{Title: "Alpha", Score: 30, Comments: 1, Source: "hn", PublishedAt: day.Add(time.Hour)},
+		{Title: "gamma", Score: 10, Comments: 2, Source: "hn", PublishedAt: day.Add(-time.Hour)},
+	}
+	titles := func(key string, desc bool) []string {
+		sorted := append([]Story(nil), news...)
+		assert.Nil(t, SortStories(sorted, key, desc))
+		var titles []string
+		for _, story := range sorted {
+			titles = append(titles, story.Title)
+		}
+		return titles
+	}
+
+	assert.Equal(t, []string{"beta", "gamma", "Alpha"}, titles(SortScore, false))
+	assert.Equal(t, []string{"Alpha", "beta", "gamma"}, titles(SortScore, true))
+	assert.Equal(t, []string{"beta", "gamma", "Alpha"}, titles(SortComments, true))
+	assert.Equal(t, []string{"gamma", "beta", "Alpha"}, titles(SortDate, false))
+	assert.Equal(t, []string{"Alpha", "gamma", "beta"}, titles(SortSource, false))
+	assert.Equal(t, []string{"Alpha", "beta", "gamma"}, titles(SortTitle, false))
+
+	assert.NotNil(t, SortStories(news, "points", false))
+}
+
+func TestShuffleAndSample(t *testing.T) {
+	var news []Story
+	for i := 0; i < 20; i++ {
+		news = append(news, Story{Score: i})
+	}
+	rng := rand.New(rand.NewSource(1))
+
+	shuffled := append([]Story(nil), news...)
+	Shuffle(shuffled, rng)
+	assert.NotEqual(t, news, shuffled)
+	assert.ElementsMatch(t, news, shuffled)
+
+	sample := Sample(news, 5, rng)
+	assert.Len(t, sample, 5)
+	for i := 1; i < len(sample); i++ {
+		assert.True(t, sample[i-1].Score < sample[i].Score, "the sample keeps the order of the stories")
+	}
+	assert.Equal(t, news, Sample(news, 30, rng))
+}
@@ -0,0 +1,114 @@
+package hnreader
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// StackExchangeAPIURL is the base of the Stack Exchange API
+const StackExchangeAPIURL = "https://api.stackexchange.com/2.3"
+
+// stackOverflowModes maps the listing modes to the API's sort orders
+var stackOverflowModes = map[string]string{
+	"hot":    "hot",
+	"newest": "creation",
+	"active": "activity",
+	"votes":  "votes",
+}
+
+func init() {
+	Register(Source{
+		Name:        "stackoverflow",
+		Description: "Hot or newest Stack Overflow questions, optionally by tag",
+		Flags:       []string{"mode", "tags"},
+		New: func(opts Options) Fetcher {
+			return &StackOverflowSource{Client: opts.Client, Tags: opts.Tags, Mode: opts.Mode}
+		},
+	})
+}
+
+// stackExchangeQuestions decodes the questions endpoint
+type stackExchangeQuestions struct {
+	Items []struct {
+		QuestionID   int    `json:"question_id"`
+		Title        string `json:"title"`
+		Link         string `json:"link"`
+		Score        int    `json:"score"`
+		AnswerCount  int    `json:"answer_count"`
+		CreationDate int64  `json:"creation_date"`
+		Owner        struct {
+			DisplayName string `json:"display_name"`
+		} `json:"owner"`
+	} `json:"items"`
+}
+
+// StackOverflowSource fetches questions from https://stackoverflow.com/
+// through the Stack Exchange API
+type StackOverflowSource struct {
+	Client *http.Client
+	// Tags restricts the questions to these tags, like "go"
+	Tags []string
+	// Mode selects the order: hot (default), newest, active or votes
+	Mode string
+}
+
+// Fetch gets the questions. With tags, the questions of every tag are
+// interleaved.
+func (l *StackOverflowSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	sort, err := lookupMode("stackoverflow mode", l.Mode, "hot", stackOverflowModes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.Tags) == 0 {
+		return l.fetchQuestions(ctx, sort, "", count)
+	}
+
+	lists, err := fetchLists(ctx, 0, len(l.Tags)-1, len(l.Tags), func(ctx context.Context, i int) ([]Story, error) {
+		return l.fetchQuestions(ctx, sort, l.Tags[i], count)
+	})
+	return interleave(lists, count), err
+}
+
+// fetchQuestions gets up to count questions, all of them when tag is empty
+func (l *StackOverflowSource) fetchQuestions(ctx context.Context, sort, tag string, count int) ([]Story, error) {
+	// a page holds at most 100 questions
+	if count > 100 {
+		count = 100
+	}
+
+	v := url.Values{}
+	v.Set("site", "stackoverflow")
+	v.Set("order", "desc")
+	v.Set("sort", sort)
+	v.Set("pagesize", strconv.Itoa(count))
+	if tag != "" {
+		v.Set("tagged", tag)
+	}
+
+	var doc stackExchangeQuestions
+	if err := getJSON(ctx, l.Client, StackExchangeAPIURL+"/questions?"+v.Encode(), &doc); err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	for _, item := range doc.Items {
+		news = append(news, Story{
+			// titles come HTML escaped
+			Title:       html.UnescapeString(item.Title),
+			URL:         item.Link,
+			Score:       item.Score,
+			HasScore:    true,
+			Comments:    item.AnswerCount,
+			Author:      html.UnescapeString(item.Owner.DisplayName),
+			Source:      "stackoverflow",
+			PublishedAt: time.Unix(item.CreationDate, 0).UTC(),
+		})
+	}
+
+	return news, nil
+}
@@ -0,0 +1,40 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackOverflowFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/2.3/questions", r.URL.Path)
+		assert.Equal(t, "creation", q.Get("sort"))
+		assert.Equal(t, "stackoverflow", q.Get("site"))
+		tag := q.Get("tagged")
+		w.Write([]byte(`{"items": [
+			{"question_id": 1, "title": "` + tag + ` &quot;one&quot;", "link": "https://stackoverflow.com/q/1/` + tag + `", "score": 5, "answer_count": 2, "creation_date": 1538492645},
+			{"question_id": 2, "title": "` + tag + ` two", "link": "https://stackoverflow.com/q/2/` + tag + `", "score": 1, "answer_count": 0, "creation_date": 1538492645}
+		]}`))
+	}))
+	defer done()
+
+	src := &StackOverflowSource{Client: client, Tags: []string{"go", "concurrency"}, Mode: "newest"}
+	news, err := src.Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, `go "one"`, news[0].Title)
+		assert.Equal(t, "https://stackoverflow.com/q/1/go", news[0].URL)
+		assert.Equal(t, 5, news[0].Score)
+		assert.Equal(t, 2, news[0].Comments)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+		assert.Equal(t, `concurrency "one"`, news[1].Title)
+		assert.Equal(t, "go two", news[2].Title)
+	}
+
+	_, err = (&StackOverflowSource{Client: client, Mode: "cold"}).Fetch(context.Background(), 3)
+	assert.NotNil(t, err)
+}
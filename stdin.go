@@ -0,0 +1,34 @@
+package hnreader
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+func init() {
+	Register(Source{
+		Name:         "stdin",
+		Description:  "URLs piped to hnreader, one per line",
+		NeedsOptions: true,
+		New: func(opts Options) Fetcher {
+			return &StdinSource{}
+		},
+	})
+}
+
+// StdinSource reads URLs from the standard input in the format of FileSource,
+// so other tools can pipe links into hnreader
+type StdinSource struct {
+	// Reader replaces os.Stdin when set
+	Reader io.Reader
+}
+
+// Fetch reads up to count URLs
+func (l *StdinSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	r := l.Reader
+	if r == nil {
+		r = os.Stdin
+	}
+	return readURLs(r, "stdin", count)
+}
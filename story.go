@@ -0,0 +1,116 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Story is a single news item returned by a Fetcher. The JSON form is the
+// one exchanged with plugins.
+type Story struct {
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	CommentsURL string    `json:"comments_url,omitempty"`
+	Score       int       `json:"score,omitempty"`
+	Comments    int       `json:"comments,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	// HasScore is set by the sources ranking stories by points, telling a
+	// story without points apart from one without a score
+	HasScore bool `json:"has_score,omitempty"`
+	// ReadingMinutes is filled in by EstimateReadingTimes
+	ReadingMinutes int `json:"reading_minutes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, leaving out the publication time of
+// undated stories rather than writing the zero time.
+func (s Story) MarshalJSON() ([]byte, error) {
+	// story has the fields of Story without its methods
+	type story Story
+	out := struct {
+		story
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+	}{story: story(s)}
+	if !s.PublishedAt.IsZero() {
+		out.PublishedAt = &s.PublishedAt
+	}
+	return json.Marshal(out)
+}
+
+// Domain returns the host the story links to without its www. prefix, like
+// "example.com", or "" when its URL can't be parsed.
+func (s Story) Domain() string {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(u.Hostname(), "www."))
+}
+
+// tags trims the given tags and drops the empty ones
+func tags(values []string) []string {
+	var tags []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			tags = append(tags, value)
+		}
+	}
+	return tags
+}
+
+// key identifies the story within its source. The discussion page is unique
+// per submission, while the same URL may be submitted more than once.
+func (s Story) key() string {
+	if s.CommentsURL != "" {
+		return s.CommentsURL
+	}
+	return s.URL
+}
+
+// articleKey identifies the article the story links to across sources,
+// which give different addresses to the same page
+func (s Story) articleKey() string {
+	if s.URL == "" {
+		return s.key()
+	}
+	return CanonicalURL(s.URL)
+}
+
+// Layouts tried by parseTime, covering the RSS, Atom and scraped formats we see
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+}
+
+// parseTime parses a date string in any of the known layouts, returning the
+// zero time when none of them match
+func parseTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// leadingInt returns the number at the start of text such as "42 points",
+// or 0 when there is none
+func leadingInt(text string) int {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(fields[0])
+	return n
+}
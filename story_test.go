@@ -0,0 +1,49 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	expected := time.Date(2018, time.October, 2, 15, 4, 5, 0, time.UTC)
+
+	assert.True(t, expected.Equal(parseTime("Tue, 02 Oct 2018 15:04:05 +0000")))
+	assert.True(t, expected.Equal(parseTime("Tue, 2 Oct 2018 15:04:05 GMT")))
+	assert.True(t, expected.Equal(parseTime(" 2018-10-02T15:04:05Z ")))
+	assert.True(t, expected.Equal(parseTime("2018-10-02 15:04:05 +0000")))
+	assert.True(t, expected.Equal(parseTime("2018-10-02T15:04:05")))
+	assert.True(t, parseTime("yesterday").IsZero())
+}
+
+func TestLeadingInt(t *testing.T) {
+	assert.Equal(t, 42, leadingInt("42 points"))
+	assert.Equal(t, 7, leadingInt(" 7 "))
+	assert.Equal(t, 0, leadingInt(""))
+	assert.Equal(t, 0, leadingInt("points"))
+}
+
+func TestStoryDomain(t *testing.T) {
+	assert.Equal(t, "example.com", Story{URL: "https://www.Example.com/post?id=1"}.Domain())
+	assert.Equal(t, "blog.example.com", Story{URL: "http://blog.example.com:8080/"}.Domain())
+	assert.Equal(t, "", Story{URL: "::not a url"}.Domain())
+}
+
+func TestStoryJSON(t *testing.T) {
+	data, err := json.Marshal(Story{Title: "Undated", URL: "https://example.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"title":"Undated","url":"https://example.com"}`, string(data))
+
+	published := time.Date(2018, 10, 2, 15, 4, 5, 0, time.UTC)
+	story := Story{Title: "Dated", URL: "https://example.com", Score: 3, HasScore: true, PublishedAt: published}
+	data, err = json.Marshal(story)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"title":"Dated","url":"https://example.com","score":3,"has_score":true,"published_at":"2018-10-02T15:04:05Z"}`, string(data))
+
+	var decoded Story
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, story, decoded)
+}
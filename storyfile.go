@@ -0,0 +1,39 @@
+package hnreader
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LoadStories reads the stories saved by SaveStories in the file at path. A
+// missing file has no stories.
+func LoadStories(path string) ([]Story, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	if err := json.Unmarshal(data, &news); err != nil {
+		return nil, err
+	}
+	return news, nil
+}
+
+// SaveStories writes the stories to the file at path as a JSON array,
+// creating its directory when needed.
+func SaveStories(path string, news []Story) error {
+	data, err := json.MarshalIndent(news, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,31 @@
+package hnreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveStories(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hnreader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "last.json")
+
+	news, err := LoadStories(path)
+	assert.Nil(t, err)
+	assert.Nil(t, news)
+
+	saved := []Story{
+		{Title: "First", URL: "https://example.com/1", Score: 42, Source: "hn", PublishedAt: time.Date(2018, 10, 2, 15, 4, 5, 0, time.UTC)},
+		{Title: "Second", URL: "https://example.com/2"},
+	}
+	assert.Nil(t, SaveStories(path, saved))
+	news, err = LoadStories(path)
+	assert.Nil(t, err)
+	assert.Equal(t, saved, news)
+}
@@ -0,0 +1,29 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+)
+
+// TheRegisterURL is The Register's headlines feed
+const TheRegisterURL = "https://www.theregister.com/headlines.atom"
+
+func init() {
+	Register(Source{
+		Name:        "register",
+		Description: "Headlines from theregister.com",
+		New: func(opts Options) Fetcher {
+			return &TheRegisterSource{Client: opts.Client}
+		},
+	})
+}
+
+// TheRegisterSource fetches the headlines of https://www.theregister.com/
+type TheRegisterSource struct {
+	Client *http.Client
+}
+
+// Fetch gets news from The Register
+func (l *TheRegisterSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	return fetchFeed(ctx, l.Client, TheRegisterURL, "register", count)
+}
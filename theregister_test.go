@@ -0,0 +1,22 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheRegisterFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/headlines.atom", r.URL.Path)
+		w.Write([]byte(atomFeed))
+	}))
+	defer done()
+
+	news, err := (&TheRegisterSource{Client: client}).Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, "register", news[0].Source)
+}
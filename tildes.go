@@ -0,0 +1,94 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// TildesURL is the Tildes homepage
+const TildesURL = "https://tildes.net"
+
+// DefaultTildesGroups are read when no group is given
+var DefaultTildesGroups = []string{"comp", "tech"}
+
+func init() {
+	Register(Source{
+		Name:        "tildes",
+		Description: "Topics from the ~comp and ~tech groups of tildes.net",
+		Flags:       []string{"group"},
+		New: func(opts Options) Fetcher {
+			return &TildesSource{Client: opts.Client, Groups: opts.Groups}
+		},
+	})
+}
+
+// TildesSource scrapes the topics of groups on https://tildes.net/
+type TildesSource struct {
+	Client *http.Client
+	// Groups such as "comp" or "~tech", DefaultTildesGroups when empty
+	Groups []string
+}
+
+// Fetch gets the topics of every group and interleaves them
+func (l *TildesSource) Fetch(ctx context.Context, count int) ([]Story, error) {
+	groups := l.Groups
+	if len(groups) == 0 {
+		groups = DefaultTildesGroups
+	}
+
+	lists, err := fetchLists(ctx, 0, len(groups)-1, len(groups), func(ctx context.Context, i int) ([]Story, error) {
+		return l.fetchGroup(ctx, strings.TrimPrefix(groups[i], "~"))
+	})
+	return interleave(lists, count), err
+}
+
+// fetchGroup scrapes the first page of topics of a group
+func (l *TildesSource) fetchGroup(ctx context.Context, group string) ([]Story, error) {
+	resp, err := get(ctx, l.Client, TildesURL+"/~"+group)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []Story
+	doc.Find("article.topic").Each(func(_ int, s *goquery.Selection) {
+		link := s.Find(".topic-title a").First()
+		href, exist := link.Attr("href")
+		if !exist {
+			return
+		}
+
+		comments := s.Find(".topic-info-comments a").First()
+		commentsURL, _ := comments.Attr("href")
+		// text topics link to their own discussion
+		if strings.HasPrefix(href, "/") {
+			href = TildesURL + href
+		}
+		if strings.HasPrefix(commentsURL, "/") {
+			commentsURL = TildesURL + commentsURL
+		}
+		published, _ := s.Find("time").First().Attr("datetime")
+
+		news = append(news, Story{
+			Title:       strings.TrimSpace(link.Text()),
+			URL:         href,
+			CommentsURL: commentsURL,
+			Score:       leadingInt(s.Find(".topic-voting-votes").Text()),
+			HasScore:    true,
+			Comments:    leadingInt(comments.Text()),
+			Source:      "tildes",
+			PublishedAt: parseTime(published),
+		})
+	})
+
+	return news, nil
+}
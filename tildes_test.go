@@ -0,0 +1,47 @@
+package hnreader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tildesPage = `<html><body><ol class="topic-listing">
+<li><article class="topic" id="topic-a1">
+	<header><h1 class="topic-title"><a href="https://example.com/GROUP">GROUP link</a></h1></header>
+	<div class="topic-info"><span class="topic-info-comments"><a href="/~GROUP/a1/link"><span>12 comments</span></a></span>
+	<time class="time-responsive" datetime="2018-10-02T15:04:05Z">1h</time></div>
+	<div class="topic-voting"><span class="topic-voting-votes">34</span></div>
+</article></li>
+<li><article class="topic" id="topic-a2">
+	<header><h1 class="topic-title"><a href="/~GROUP/a2/ask">GROUP question</a></h1></header>
+</article></li>
+</ol></body></html>`
+
+func TestTildesFetch(t *testing.T) {
+	client, done := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group := strings.TrimPrefix(r.URL.Path, "/~")
+		w.Write([]byte(strings.Replace(tildesPage, "GROUP", group, -1)))
+	}))
+	defer done()
+
+	news, err := (&TildesSource{Client: client}).Fetch(context.Background(), 3)
+	assert.Nil(t, err)
+	if assert.Len(t, news, 3) {
+		assert.Equal(t, "comp link", news[0].Title)
+		assert.Equal(t, "https://example.com/comp", news[0].URL)
+		assert.Equal(t, TildesURL+"/~comp/a1/link", news[0].CommentsURL)
+		assert.Equal(t, 34, news[0].Score)
+		assert.Equal(t, 12, news[0].Comments)
+		assert.Equal(t, 2018, news[0].PublishedAt.Year())
+		assert.Equal(t, "tech link", news[1].Title)
+		assert.Equal(t, TildesURL+"/~comp/a2/ask", news[2].URL)
+	}
+
+	news, err = (&TildesSource{Client: client, Groups: []string{"~games"}}).Fetch(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "games link", news[0].Title)
+}
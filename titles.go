@@ -0,0 +1,106 @@
+package hnreader
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+)
+
+// TitleSimilarity is the levenshtein ratio from which two titles are taken
+// for the same story by SimilarTitles
+const TitleSimilarity = 0.85
+
+// titlePrefixes are left out of titles before comparing them, as only some
+// sources add them
+var titlePrefixes = []string{"show hn ", "ask hn ", "launch hn ", "tell hn "}
+
+// normalizeTitle lowercases the title and keeps its words, one space apart
+func normalizeTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	normalized := strings.Join(words, " ")
+	for _, prefix := range titlePrefixes {
+		normalized = strings.TrimPrefix(normalized, prefix)
+	}
+	return normalized
+}
+
+// numbers returns the digits of a normalized title, which tell apart
+// titles like "Go 1.22.1 is released" and "Go 1.22.2 is released"
+func numbers(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, title)
+}
+
+// SimilarTitles tells whether two titles are likely those of the same story,
+// their words being at least TitleSimilarity alike and their numbers equal.
+func SimilarTitles(a, b string) bool {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == "" || b == "" || numbers(a) != numbers(b) {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	// titles of too different lengths can't be alike enough, and measuring
+	// them is the slow part
+	ra, rb := []rune(a), []rune(b)
+	diff := len(ra) - len(rb)
+	if diff < 0 {
+		diff = -diff
+	}
+	if float64(diff) > (1-TitleSimilarity)*float64(len(ra)+len(rb)) {
+		return false
+	}
+	return levenshtein.RatioForStrings(ra, rb, levenshtein.DefaultOptions) >= TitleSimilarity
+}
+
+// collapseSimilarTitles leaves out of the lists the stories whose title is
+// similar to that of a story of another list with a higher score, keeping
+// the one of the first list when their scores are equal
+func collapseSimilarTitles(lists [][]Story) [][]Story {
+	type ref struct{ list, i int }
+	var refs []ref
+	for list, stories := range lists {
+		for i := range stories {
+			refs = append(refs, ref{list, i})
+		}
+	}
+
+	dropped := make(map[ref]bool)
+	for a, first := range refs {
+		for _, second := range refs[a+1:] {
+			if first.list == second.list || dropped[first] || dropped[second] {
+				continue
+			}
+			x, y := lists[first.list][first.i], lists[second.list][second.i]
+			if !SimilarTitles(x.Title, y.Title) {
+				continue
+			}
+			if y.Score > x.Score {
+				dropped[first] = true
+			} else {
+				dropped[second] = true
+			}
+		}
+	}
+	if len(dropped) == 0 {
+		return lists
+	}
+
+	collapsed := make([][]Story, len(lists))
+	for list, stories := range lists {
+		for i, story := range stories {
+			if !dropped[ref{list, i}] {
+				collapsed[list] = append(collapsed[list], story)
+			}
+		}
+	}
+	return collapsed
+}
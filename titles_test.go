@@ -0,0 +1,38 @@
+package hnreader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarTitles(t *testing.T) {
+	assert.True(t, SimilarTitles("Why SQLite is so great", "Why SQLite Is So Great!"))
+	assert.True(t, SimilarTitles("Show HN: A tiny Lisp in 500 lines of Go", "A tiny Lisp in 500 lines of Go"))
+	assert.True(t, SimilarTitles("The unreasonable effectiveness of plain text", "The Unreasonable Effectiveness of Plain-Text"))
+	assert.True(t, SimilarTitles("The unreasonable effectiveness of plain text", "The unreasonable effectivness of plain text"))
+	assert.False(t, SimilarTitles("Go 1.22.1 is released", "Go 1.22.2 is released"))
+	assert.False(t, SimilarTitles("Why SQLite is so great", "Why Postgres is so great"))
+	assert.False(t, SimilarTitles("Rust", "Rust in production at a large company"))
+	assert.False(t, SimilarTitles("", ""))
+}
+
+func TestMultiSourceSimilarTitles(t *testing.T) {
+	hn := staticSource{
+		{Title: "Why SQLite is so great", URL: "https://example.com/sqlite", Score: 120, Source: "hn"},
+		{Title: "Something else", URL: "https://example.com/else", Score: 50, Source: "hn"},
+	}
+	reddit := staticSource{
+		{Title: "Why SQLite is so great!", URL: "https://example.com/sqlite?from=reddit", Score: 800, Source: "reddit"},
+		{Title: "Something else entirely", URL: "https://example.com/other", Score: 10, Source: "reddit"},
+	}
+	lobsters := staticSource{
+		{Title: "why sqlite is so great", URL: "https://mirror.example.org/sqlite", Score: 30, Source: "lobsters"},
+	}
+	m := &MultiSource{Fetchers: []Fetcher{hn, reddit, lobsters}}
+	news, err := m.Fetch(context.Background(), 10)
+	assert.Nil(t, err)
+	// only the best scored of the similar stories is kept
+	assert.Equal(t, []string{"https://example.com/else", "https://example.com/sqlite?from=reddit", "https://example.com/other"}, urlsOf(news))
+}